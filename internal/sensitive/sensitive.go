@@ -0,0 +1,119 @@
+// Package sensitive holds the key names whose values are hidden by apperr (in the details of
+// errors) and logz (in log entries), so both redact the same keys.
+package sensitive
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// RedactedValue replaces the values of sensitive keys.
+const RedactedValue = "[REDACTED]"
+
+var (
+	// mu guards keys.
+	mu sync.RWMutex
+
+	// keys are the normalized fragments that mark a key as sensitive.
+	keys = []string{
+		"password", "passwd", "secret", "token", "authorization", "apikey",
+		"cookie", "session", "privatekey", "creditcard", "cardnumber", "cvv", "ssn",
+	}
+
+	// keyReplacer removes the separators of a key.
+	keyReplacer = strings.NewReplacer("-", "", "_", "", ".", "", " ", "")
+)
+
+// Register adds keys to the sensitive ones.
+func Register(k ...string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	for _, key := range k {
+		if n := NormalizeKey(key); n != "" && !slices.Contains(keys, n) {
+			keys = append(keys, n)
+		}
+	}
+}
+
+// Keys returns the normalized sensitive key fragments.
+func Keys() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return slices.Clone(keys)
+}
+
+// IsKey reports whether key holds a sensitive fragment as whole segments (see Match), so
+// "X-Api-Key", "user_password" and "accessToken" are sensitive but "business_name" and
+// "max_tokens" are not.
+func IsKey(key string) bool {
+	segments := Split(key)
+
+	mu.RLock()
+	defer mu.RUnlock()
+
+	for _, s := range keys {
+		if Match(segments, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// Match reports whether the normalized fragment equals one of the segments or several
+// consecutive ones joined, e.g. "apikey" matches both ["apikey"] and ["x", "api", "key"].
+func Match(segments []string, fragment string) bool {
+	for i := range segments {
+		joined := ""
+		for _, s := range segments[i:] {
+			if joined += s; len(joined) >= len(fragment) {
+				if joined == fragment {
+					return true
+				}
+				break
+			}
+		}
+	}
+	return false
+}
+
+// Split returns the lowercased segments of key, separated by any character that is not a
+// letter or a digit and by camelCase boundaries: "X-Api-Key", "x_api_key" and "XApiKey" all
+// give ["x", "api", "key"].
+func Split(key string) []string {
+	var (
+		segments []string
+		current  []rune
+	)
+	flush := func() {
+		if len(current) > 0 {
+			segments = append(segments, strings.ToLower(string(current)))
+			current = current[:0]
+		}
+	}
+
+	runes := []rune(key)
+	for i, c := range runes {
+		if !unicode.IsLetter(c) && !unicode.IsDigit(c) {
+			flush()
+			continue
+		}
+		if unicode.IsUpper(c) && i > 0 {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				flush()
+			}
+		}
+		current = append(current, c)
+	}
+	flush()
+	return segments
+}
+
+// NormalizeKey lowercases key and removes its separators ("-", "_", "." and spaces).
+func NormalizeKey(key string) string {
+	return keyReplacer.Replace(strings.ToLower(key))
+}
@@ -0,0 +1,44 @@
+package sensitive
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIsKey(t *testing.T) {
+	for _, key := range []string{
+		"password", "X-Api-Key", "api_key", "APIKey", "session_id", "user.SSN", "Access-Token",
+		"accessToken", "db_password", "private-key", "credit_card_number",
+	} {
+		if !IsKey(key) {
+			t.Errorf("expected %q to be sensitive", key)
+		}
+	}
+	for _, key := range []string{"username", "business_name", "class_name", "address_number", "max_tokens", "tokenizer"} {
+		if IsKey(key) {
+			t.Errorf("expected %q not to be sensitive", key)
+		}
+	}
+
+	Register("Tax-ID")
+	if !IsKey("customer_tax_id") {
+		t.Error("expected a registered key to be sensitive")
+	}
+}
+
+func TestSplit(t *testing.T) {
+	tests := map[string]string{
+		"X-Api-Key":      "x api key",
+		"accessToken":    "access token",
+		"APIKey":         "api key",
+		"user.SSN":       "user ssn",
+		"oauth2Token":    "oauth2 token",
+		"business_name":  "business name",
+		"Content Length": "content length",
+	}
+	for key, want := range tests {
+		if got := strings.Join(Split(key), " "); got != want {
+			t.Errorf("Split(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
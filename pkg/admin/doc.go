@@ -0,0 +1,31 @@
+/*
+Package admin provides Echo handlers for operating the application at runtime.
+
+LogLevelHandler reads and changes the levels of the loggers (see logz.Named and
+logz.SetNamedLevel) without restarting the application, e.g. to turn on DEBUG for pgutil while
+investigating a production incident. A change can be temporary: with a TTL the previous level
+is restored when it expires. Expose these routes only on an internal port or behind RBAC.
+
+Features:
+  - Lists the effective level of the global logger ("root") and of every named logger.
+  - Changes a level permanently or for a TTL, bounded with WithMaxTTL.
+  - Resets a named logger to follow its parent, or the global logger to LOG_LEVEL.
+  - Only acts on existing loggers: unknown names get ErrResourceNotFound, so a typo or a scan
+    never adds entries to the registry of logz.
+  - Logs every change at WARN level and reports errors as apperr errors for mw.AppErrorHandler.
+
+Example usage:
+
+	// Named loggers for the components
+	db := pgutil.GetPostgresClient(logz.Global().Named("pgutil"), dbCfg)
+
+	levels := admin.NewLogLevelHandler(logger, admin.WithMaxTTL(time.Hour))
+	g := e.Group("/admin/log-levels", authorizer.Guard(permAdmin))
+	g.GET("", levels.ListLevels)
+	g.GET("/:name", levels.GetLevel)
+	g.PUT("/:name", levels.SetLevel)
+	g.DELETE("/:name", levels.ResetLevel)
+
+	// curl -X PUT /admin/log-levels/pgutil -d '{"level":"DEBUG","ttl":"15m"}'
+*/
+package admin
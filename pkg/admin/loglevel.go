@@ -0,0 +1,144 @@
+package admin
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nochebuenadev/go-kit/pkg/apperr"
+	"github.com/nochebuenadev/go-kit/pkg/binder"
+	"github.com/nochebuenadev/go-kit/pkg/logz"
+)
+
+type (
+	// LogLevelHandler defines the Echo handlers that read and change the levels of the loggers
+	// at runtime. The logger is named in the ":name" path parameter; logz.RootLogger refers to
+	// the global logger.
+	LogLevelHandler interface {
+		// ListLevels returns the levels of the global logger and of every named logger.
+		ListLevels(c echo.Context) error
+		// GetLevel returns the level of a logger.
+		GetLevel(c echo.Context) error
+		// SetLevel changes the level of a logger, temporarily when the request has a TTL.
+		SetLevel(c echo.Context) error
+		// ResetLevel makes a logger follow the level of its parent again.
+		ResetLevel(c echo.Context) error
+	}
+
+	// SetLevelRequest is the body of SetLevel.
+	SetLevelRequest struct {
+		// Name is the logger, taken from the path.
+		Name string `param:"name" json:"-"`
+		// Level is the new level: DEBUG, INFO, WARN or ERROR.
+		Level string `json:"level" validate:"required" normalize:"trim"`
+		// TTL is how long the level lasts before the previous one is restored, as a duration
+		// (e.g. "15m"). Empty keeps it until it is changed again.
+		TTL string `json:"ttl" normalize:"trim"`
+	}
+
+	// logLevelHandler is the concrete implementation of LogLevelHandler.
+	logLevelHandler struct {
+		// logger records the level changes.
+		logger logz.Logger
+		// maxTTL bounds the TTL of temporary levels, or zero for no bound.
+		maxTTL time.Duration
+	}
+
+	// LogLevelOption configures a handler created with NewLogLevelHandler.
+	LogLevelOption func(*logLevelHandler)
+)
+
+// WithMaxTTL rejects temporary levels longer than d, so a forgotten DEBUG level does not stay
+// on for long in production.
+func WithMaxTTL(d time.Duration) LogLevelOption {
+	return func(h *logLevelHandler) {
+		h.maxTTL = d
+	}
+}
+
+// NewLogLevelHandler creates the handlers of the log level admin routes. Every change is logged
+// at WARN level with logger.
+func NewLogLevelHandler(logger logz.Logger, opts ...LogLevelOption) LogLevelHandler {
+	h := &logLevelHandler{logger: logger}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// ListLevels implements LogLevelHandler.
+func (h *logLevelHandler) ListLevels(c echo.Context) error {
+	return c.JSON(http.StatusOK, logz.Levels())
+}
+
+// GetLevel implements LogLevelHandler. It returns ErrResourceNotFound for a logger that does
+// not exist.
+func (h *logLevelHandler) GetLevel(c echo.Context) error {
+	if err := requireLogger(c.Param("name")); err != nil {
+		return err
+	}
+	status, _ := logz.NamedLevel(c.Param("name"))
+	return c.JSON(http.StatusOK, status)
+}
+
+// SetLevel implements LogLevelHandler. It returns ErrResourceNotFound for a logger that does
+// not exist, and ErrInvalidInput for an unknown level, an invalid TTL or one above the maximum.
+func (h *logLevelHandler) SetLevel(c echo.Context) error {
+	req, err := binder.Bind[SetLevelRequest](c)
+	if err != nil {
+		return err
+	}
+	if err := requireLogger(req.Name); err != nil {
+		return err
+	}
+
+	level, err := logz.ParseLevel(req.Level)
+	if err != nil {
+		return apperr.InvalidInput("Nivel de log no válido").WithContext("level", req.Level)
+	}
+
+	var ttl time.Duration
+	if req.TTL != "" {
+		if ttl, err = time.ParseDuration(req.TTL); err != nil || ttl <= 0 {
+			return apperr.InvalidInput("Duración no válida").WithContext("ttl", req.TTL)
+		}
+		if h.maxTTL > 0 && ttl > h.maxTTL {
+			return apperr.InvalidInput("La duración supera el máximo permitido").
+				WithContext("ttl", req.TTL).WithContext("max_ttl", h.maxTTL.String())
+		}
+	}
+
+	logz.SetNamedLevel(req.Name, level, ttl)
+	h.logger.WarnCtx(c.Request().Context(), "admin: nivel de log cambiado",
+		"target", req.Name,
+		"level", level.String(),
+		"ttl", ttl.String(),
+	)
+
+	status, _ := logz.NamedLevel(req.Name)
+	return c.JSON(http.StatusOK, status)
+}
+
+// ResetLevel implements LogLevelHandler. It returns ErrResourceNotFound for a logger that does
+// not exist.
+func (h *logLevelHandler) ResetLevel(c echo.Context) error {
+	name := c.Param("name")
+	if err := requireLogger(name); err != nil {
+		return err
+	}
+
+	logz.ResetNamedLevel(name)
+	h.logger.WarnCtx(c.Request().Context(), "admin: nivel de log restablecido", "target", name)
+
+	status, _ := logz.NamedLevel(name)
+	return c.JSON(http.StatusOK, status)
+}
+
+// requireLogger returns ErrResourceNotFound unless the logger exists, so the handlers never
+// add unknown names to the registry of logz. logz.RootLogger always exists.
+func requireLogger(name string) error {
+	if _, ok := logz.NamedLevel(name); !ok {
+		return apperr.NotFound("Logger no encontrado").WithContext("name", name)
+	}
+	return nil
+}
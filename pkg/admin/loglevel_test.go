@@ -0,0 +1,149 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nochebuenadev/go-kit/pkg/apperr"
+	"github.com/nochebuenadev/go-kit/pkg/logz"
+)
+
+type mockLogger struct{}
+
+func (m *mockLogger) Debug(msg string, args ...any)                                       {}
+func (m *mockLogger) Info(msg string, args ...any)                                        {}
+func (m *mockLogger) Warn(msg string, args ...any)                                        {}
+func (m *mockLogger) Error(msg string, err error, args ...any)                            {}
+func (m *mockLogger) LogError(msg string, err error, args ...any)                         {}
+func (m *mockLogger) Fatal(msg string, err error, args ...any)                            {}
+func (m *mockLogger) DebugCtx(ctx context.Context, msg string, args ...any)               {}
+func (m *mockLogger) InfoCtx(ctx context.Context, msg string, args ...any)                {}
+func (m *mockLogger) WarnCtx(ctx context.Context, msg string, args ...any)                {}
+func (m *mockLogger) ErrorCtx(ctx context.Context, msg string, err error, args ...any)    {}
+func (m *mockLogger) LogErrorCtx(ctx context.Context, msg string, err error, args ...any) {}
+func (m *mockLogger) With(args ...any) logz.Logger                                        { return m }
+func (m *mockLogger) WithContext(ctx context.Context) logz.Logger                         { return m }
+func (m *mockLogger) Named(name string) logz.Logger                                       { return m }
+
+// newContext returns an Echo context for a request on the logger name.
+func newContext(method, name, body string) (echo.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(method, "/admin/log-levels/"+name, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	}
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("name")
+	c.SetParamValues(name)
+	return c, rec
+}
+
+func TestLogLevelHandler_SetLevel(t *testing.T) {
+	h := NewLogLevelHandler(&mockLogger{}, WithMaxTTL(time.Hour))
+	logz.ResetNamedLevel("admin-test")
+
+	t.Run("permanent", func(t *testing.T) {
+		c, rec := newContext(http.MethodPut, "admin-test", `{"level":"debug"}`)
+		if err := h.SetLevel(c); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", rec.Code)
+		}
+
+		var status logz.LevelStatus
+		if err := json.Unmarshal(rec.Body.Bytes(), &status); err != nil {
+			t.Fatalf("invalid JSON: %v", err)
+		}
+		if status.Name != "admin-test" || status.Level != slog.LevelDebug || status.Inherited {
+			t.Errorf("unexpected status: %+v", status)
+		}
+	})
+
+	t.Run("temporary", func(t *testing.T) {
+		c, _ := newContext(http.MethodPut, "admin-test", `{"level":"ERROR","ttl":"10m"}`)
+		if err := h.SetLevel(c); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if status, _ := logz.NamedLevel("admin-test"); status.ExpiresAt.IsZero() {
+			t.Errorf("expected an expiration, got %+v", status)
+		}
+	})
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"unknown level", `{"level":"verbose"}`},
+		{"missing level", `{}`},
+		{"invalid ttl", `{"level":"DEBUG","ttl":"soon"}`},
+		{"ttl above maximum", `{"level":"DEBUG","ttl":"2h"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := newContext(http.MethodPut, "admin-test", tt.body)
+			if err := h.SetLevel(c); !apperr.IsCode(err, apperr.ErrInvalidInput) {
+				t.Errorf("expected ErrInvalidInput, got %v", err)
+			}
+		})
+	}
+
+	t.Run("root logger", func(t *testing.T) {
+		t.Cleanup(func() { logz.SetLevel(slog.LevelInfo) })
+
+		c, _ := newContext(http.MethodPut, logz.RootLogger, `{"level":"warn"}`)
+		if err := h.SetLevel(c); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("unknown logger", func(t *testing.T) {
+		c, _ := newContext(http.MethodPut, "admin-typo", `{"level":"debug"}`)
+		if err := h.SetLevel(c); !apperr.IsCode(err, apperr.ErrResourceNotFound) {
+			t.Errorf("expected ErrResourceNotFound, got %v", err)
+		}
+		if _, ok := logz.NamedLevel("admin-typo"); ok {
+			t.Error("expected the unknown logger not to be registered")
+		}
+	})
+}
+
+func TestLogLevelHandler_GetListReset(t *testing.T) {
+	h := NewLogLevelHandler(&mockLogger{})
+	logz.SetNamedLevel("admin-reset", slog.LevelWarn, 0)
+
+	c, rec := newContext(http.MethodGet, "admin-reset", "")
+	if err := h.GetLevel(c); err != nil || !strings.Contains(rec.Body.String(), `"level":"WARN"`) {
+		t.Errorf("expected WARN, got %v %s", err, rec.Body.String())
+	}
+
+	c, _ = newContext(http.MethodGet, "does-not-exist", "")
+	if err := h.GetLevel(c); !apperr.IsCode(err, apperr.ErrResourceNotFound) {
+		t.Errorf("expected ErrResourceNotFound, got %v", err)
+	}
+
+	c, rec = newContext(http.MethodGet, "", "")
+	if err := h.ListLevels(c); err != nil || !strings.Contains(rec.Body.String(), `"name":"admin-reset"`) {
+		t.Errorf("expected admin-reset in the list, got %v %s", err, rec.Body.String())
+	}
+
+	c, rec = newContext(http.MethodDelete, "admin-reset", "")
+	if err := h.ResetLevel(c); err != nil || !strings.Contains(rec.Body.String(), `"inherited":true`) {
+		t.Errorf("expected an inherited level after reset, got %v %s", err, rec.Body.String())
+	}
+
+	c, _ = newContext(http.MethodDelete, "admin-reset-typo", "")
+	if err := h.ResetLevel(c); !apperr.IsCode(err, apperr.ErrResourceNotFound) {
+		t.Errorf("expected ErrResourceNotFound, got %v", err)
+	}
+	if _, ok := logz.NamedLevel("admin-reset-typo"); ok {
+		t.Error("expected the unknown logger not to be registered")
+	}
+}
@@ -5,8 +5,40 @@ It defines a robust error structure (AppErr) that includes error codes, human-re
 underlying causes, and contextual information. This helps in maintaining consistency across
 the application and simplifies error handling and logging.
 
-The package utilizes ErrorCode to categorize errors, allowing for easier mapping to HTTP status
-codes or other external representations.
+The package utilizes ErrorCode to categorize errors. It covers the canonical gRPC set
+(INVALID_ARGUMENT, NOT_FOUND, FAILED_PRECONDITION, RESOURCE_EXHAUSTED, ABORTED, CANCELLED...)
+and keeps a single registry mapping every code to its HTTP status, gRPC codes.Code and
+description, which every mapper in the kit relies on:
+  - HTTPStatus, GRPCCode and Description convert an ErrorCode.
+  - FromHTTPStatus and FromGRPCCode convert back to an ErrorCode; LookupHTTPStatus reports
+    whether a status is mapped at all.
+  - Register adds custom codes with their own HTTP status, gRPC code and description.
+
+AppErr works with the standard errors package: Unwrap exposes the cause, so errors.Is(err,
+pgx.ErrNoRows) still works after pgutil.HandleError, and Is matches errors by code
+(errors.Is(err, apperr.New(apperr.ErrResourceNotFound, "")) or IsCode). Sentinel declares
+package-level errors that match only the errors derived from them, and Join builds an error
+with several causes. WithContext, WithError and WithFieldViolations return copies, so shared
+errors are never modified.
+
+Details are either public or internal. WithPublic (and WithContext) add metadata rendered in
+responses by MarshalJSON and mw.AppErrorHandler; WithInternal adds diagnostics, such as table
+or constraint names, that logz.LogError logs but clients never see. The values of sensitive
+keys (password, secret, token, authorization, api_key, cookie...; see IsSensitiveKey and
+RegisterSensitiveKeys) are replaced by RedactedValue in both.
+
+Errors can be classified for retries. WithRetryable marks whether running the operation again
+may succeed, WithTemporary marks conditions expected to clear by themselves and WithRetryAfter
+sets how long to wait. IsRetryable, IsTemporary and RetryAfterOf read them from any error
+chain; ErrUnavailable, ErrDeadlineExceeded and ErrAborted are retryable by default, and other
+errors are as retryable as their cause. pgutil and mysqlutil mark deadlocks and connection
+errors, httputil keeps the Retry-After of responses, and mw.AppErrorHandler sends it back.
+
+Every AppErr records its origin (the caller of New, Wrap, etc.), available through Origin.
+The full stack trace is recorded according to the StackMode, set with SetStackMode or the
+APPERR_STACK_TRACE environment variable: StackNone (default), StackInternal (errors mapped to
+HTTP 500 only) or StackAll. StackTrace returns the recorded frames, and %+v prints the error with
+its frames followed by the causal chain. logz.LogError logs the origin as "error_origin".
 
 Example usage:
 
@@ -15,5 +47,35 @@ Example usage:
 
 	// Wrapping an existing error
 	wrappedErr := apperr.Wrap(apperr.ErrInternal, "failed to save user", originalErr)
+
+	// Validation errors with one entry per invalid field, rendered as an "errors" array
+	err = apperr.InvalidInput("invalid data").WithFieldViolations(
+		apperr.FieldViolation{Field: "email", Tag: "required", Message: "email is required"},
+	)
+
+	// Sentinels and comparison
+	var ErrUserNotFound = apperr.Sentinel(apperr.ErrResourceNotFound, "user not found")
+	err = ErrUserNotFound.WithContext("user_id", id) // a copy; ErrUserNotFound is unchanged
+	errors.Is(err, ErrUserNotFound)                   // true
+	apperr.IsCode(err, apperr.ErrResourceNotFound)    // true
+
+	// Public metadata for clients, internal diagnostics for logs only
+	err = apperr.New(apperr.ErrConflict, "user already exists").
+		WithPublic("field", "email").
+		WithInternal("constraint", "users_email_key")
+
+	// Retries
+	err = apperr.New(apperr.ErrResourceExhausted, "quota exceeded").WithRetryAfter(30 * time.Second)
+	apperr.IsRetryable(err)  // true
+	apperr.RetryAfterOf(err) // 30s
+
+	// Stack traces for internal errors
+	apperr.SetStackMode(apperr.StackInternal)
+	fmt.Printf("%+v\n", apperr.Internal("failed to save user"))
+
+	// Custom codes
+	const ErrPaymentRequired apperr.ErrorCode = "PAYMENT_REQUIRED"
+	_ = apperr.Register(ErrPaymentRequired, http.StatusPaymentRequired, codes.FailedPrecondition, "Payment required")
+	status := ErrPaymentRequired.HTTPStatus() // 402
 */
 package apperr
@@ -4,8 +4,26 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
+	"time"
 )
 
+// FieldViolationsKey is the context key holding the []FieldViolation of a validation error.
+const FieldViolationsKey = "errors"
+
+// FieldViolation describes an input field that failed validation.
+type FieldViolation struct {
+	// Field is the path of the field as seen by the client (e.g. "address.zip_code").
+	Field string `json:"field"`
+	// Tag is the validation rule that failed (e.g. "required").
+	Tag string `json:"tag"`
+	// Param is the parameter of the rule, if any (e.g. "18" for "min=18").
+	Param string `json:"param,omitempty"`
+	// Message is the human-readable description of the violation.
+	Message string `json:"message"`
+}
+
 // AppErr represents a standard application error with rich context.
 // It implements the full error interface and provides additional methods
 // for structured error reporting and logging.
@@ -14,27 +32,37 @@ type AppErr struct {
 	code ErrorCode
 	// message is the human-readable description of the error.
 	message string
+	// messageKey is the catalogue key the message was rendered from, set with WithMessageKey.
+	messageKey string
+	// messageParams are the values of the placeholders of messageKey.
+	messageParams map[string]string
 	// err is the underlying cause of the error (optional).
 	err error
-	// context contains additional metadata associated with the error (optional).
+	// context contains the public metadata of the error, sent to clients (optional).
 	context map[string]any
+	// internal contains diagnostics that are logged but never sent to clients (optional).
+	internal map[string]any
+	// sentinel is the sentinel the error was derived from, or the error itself for a sentinel.
+	sentinel *AppErr
+	// retry is the retryability set with WithRetryable, if any.
+	retry retryState
+	// temporary reports whether the condition that caused the error is expected to clear.
+	temporary bool
+	// retryAfter is the duration to wait before retrying, or zero if unknown.
+	retryAfter time.Duration
+	// stack holds the program counters of the call stack where the error was created. Only the
+	// origin is recorded unless the stack mode requires the full stack.
+	stack []uintptr
 }
 
 // New creates a new AppErr with the given code and message.
 func New(code ErrorCode, message string) *AppErr {
-	return &AppErr{
-		code:    code,
-		message: message,
-	}
+	return newAppErr(code, message, nil)
 }
 
 // Wrap creates a new AppErr that wraps an existing error with a code and message.
 func Wrap(code ErrorCode, message string, err error) *AppErr {
-	return &AppErr{
-		code:    code,
-		message: message,
-		err:     err,
-	}
+	return newAppErr(code, message, err)
 }
 
 // EnsureAppError converts a generic error into an AppErr.
@@ -50,25 +78,64 @@ func EnsureAppError(err error) *AppErr {
 		return appErr
 	}
 
-	return Wrap(ErrInternal, "Ocurrió un error inesperado", err)
+	return newAppErr(ErrInternal, "Ocurrió un error inesperado", err)
 }
 
 // InvalidInput creates a new AppErr with ErrInvalidInput code.
 // It accepts a format string and arguments for the message.
 func InvalidInput(msg string, args ...any) *AppErr {
-	return New(ErrInvalidInput, fmt.Sprintf(msg, args...))
+	return newAppErr(ErrInvalidInput, fmt.Sprintf(msg, args...), nil)
 }
 
 // NotFound creates a new AppErr with ErrResourceNotFound code.
 // It accepts a format string and arguments for the message.
 func NotFound(msg string, args ...any) *AppErr {
-	return New(ErrResourceNotFound, fmt.Sprintf(msg, args...))
+	return newAppErr(ErrResourceNotFound, fmt.Sprintf(msg, args...), nil)
 }
 
 // Internal creates a new AppErr with ErrInternal code.
 // It accepts a format string and arguments for the message.
 func Internal(msg string, args ...any) *AppErr {
-	return New(ErrInternal, fmt.Sprintf(msg, args...))
+	return newAppErr(ErrInternal, fmt.Sprintf(msg, args...), nil)
+}
+
+// Sentinel creates an error meant to be declared once at package level and compared with
+// errors.Is, e.g. var ErrUserNotFound = apperr.Sentinel(apperr.ErrResourceNotFound, "user not found").
+// The builders return copies, so ErrUserNotFound.WithContext("id", id) still matches it
+// while the sentinel itself is never modified.
+func Sentinel(code ErrorCode, message string) *AppErr {
+	e := newAppErr(code, message, nil)
+	e.sentinel = e
+	return e
+}
+
+// Join creates an AppErr caused by several errors, e.g. the failures of parallel tasks.
+// errors.Is and errors.As match any of them. Nil errors are discarded, and Join returns nil
+// if every error is nil. Like errors.Join it returns an error, so that nil result is a
+// true nil interface.
+func Join(code ErrorCode, message string, errs ...error) error {
+	joined := errors.Join(errs...)
+	if joined == nil {
+		return nil
+	}
+	return newAppErr(code, message, joined)
+}
+
+// IsCode reports whether any error in err's chain is an AppErr with the given code.
+func IsCode(err error, code ErrorCode) bool {
+	return errors.Is(err, &AppErr{code: code})
+}
+
+// newAppErr creates an AppErr recording its origin, and its stack trace when the stack mode
+// requires it. It must be called directly by the exported constructors so the recorded frames
+// start at their caller.
+func newAppErr(code ErrorCode, message string, err error) *AppErr {
+	return &AppErr{
+		code:    code,
+		message: message,
+		err:     err,
+		stack:   callers(code),
+	}
 }
 
 // Error returns a formatted string representation of the error.
@@ -84,7 +151,7 @@ func (e *AppErr) Error() string {
 }
 
 // Detailed returns a more verbose string representation of the error,
-// including its code, message, cause, and full context.
+// including its code, message, cause, and full context (public and internal).
 func (e *AppErr) Detailed() string {
 	details := fmt.Sprintf("code: %s | message: %s", e.code, e.message)
 
@@ -96,6 +163,10 @@ func (e *AppErr) Detailed() string {
 		details = fmt.Sprintf("%s | context: %v", details, e.context)
 	}
 
+	if len(e.internal) > 0 {
+		details = fmt.Sprintf("%s | internal: %v", details, e.internal)
+	}
+
 	return details
 }
 
@@ -104,8 +175,29 @@ func (e *AppErr) GetCode() string {
 	return string(e.code)
 }
 
-// GetContext returns the contextual data associated with the error.
-// If no context exists, it returns an empty map.
+// GetMessage returns the human-readable message of the error.
+func (e *AppErr) GetMessage() string {
+	return e.message
+}
+
+// GetMessageKey returns the catalogue key and placeholder values set with WithMessageKey, or an
+// empty key if the message was not rendered from the catalogue.
+func (e *AppErr) GetMessageKey() (string, map[string]string) {
+	return e.messageKey, e.messageParams
+}
+
+// WithMessageKey returns a copy of the error recording the catalogue key (see i18n.MessageKey)
+// and the placeholder values its message was rendered from, so messages with parameters, such
+// as a size limit, can be translated by mw.AppErrorHandler.
+func (e *AppErr) WithMessageKey(key string, params map[string]string) *AppErr {
+	c := e.clone()
+	c.messageKey = key
+	c.messageParams = maps.Clone(params)
+	return c
+}
+
+// GetContext returns the public contextual data associated with the error, the data that
+// may be sent to clients. If no context exists, it returns an empty map.
 func (e *AppErr) GetContext() map[string]any {
 	if e.context == nil {
 		return make(map[string]any)
@@ -114,32 +206,137 @@ func (e *AppErr) GetContext() map[string]any {
 	return e.context
 }
 
-// WithContext adds a key-value pair to the error's context.
-// It returns the modified AppErr for chaining.
+// GetInternal returns the internal diagnostics of the error, meant for logs only.
+// If there are none, it returns an empty map.
+func (e *AppErr) GetInternal() map[string]any {
+	if e.internal == nil {
+		return make(map[string]any)
+	}
+
+	return e.internal
+}
+
+// WithContext returns a copy of the error with a key-value pair added to its public context.
+// It is equivalent to WithPublic. The receiver is not modified, so shared errors such as
+// sentinels are never polluted.
 func (e *AppErr) WithContext(key string, value any) *AppErr {
+	c := e.clone()
+	c.setContext(key, value)
+	return c
+}
+
+// WithPublic returns a copy of the error with a key-value pair added to its public context,
+// which is rendered in responses. Values of sensitive keys (see IsSensitiveKey) are redacted.
+// The receiver is not modified.
+func (e *AppErr) WithPublic(key string, value any) *AppErr {
+	c := e.clone()
+	c.setContext(key, value)
+	return c
+}
+
+// WithInternal returns a copy of the error with a key-value pair added to its internal
+// diagnostics, which are logged but never rendered in responses (e.g. table or constraint
+// names). Values of sensitive keys are redacted. The receiver is not modified.
+func (e *AppErr) WithInternal(key string, value any) *AppErr {
+	c := e.clone()
+	if c.internal == nil {
+		c.internal = make(map[string]any)
+	}
+	c.internal[key] = redact(key, value)
+	return c
+}
+
+// WithFieldViolations returns a copy of the error with field violations added to its context
+// under FieldViolationsKey. The receiver is not modified.
+func (e *AppErr) WithFieldViolations(violations ...FieldViolation) *AppErr {
+	c := e.clone()
+	c.setContext(FieldViolationsKey, slices.Concat(e.GetFieldViolations(), violations))
+	return c
+}
+
+// GetFieldViolations returns the field violations associated with the error, if any.
+func (e *AppErr) GetFieldViolations() []FieldViolation {
+	violations, _ := e.context[FieldViolationsKey].([]FieldViolation)
+	return violations
+}
+
+// WithError returns a copy of the error with err as its underlying cause.
+// The receiver is not modified.
+func (e *AppErr) WithError(err error) *AppErr {
+	c := e.clone()
+	c.err = err
+	return c
+}
+
+// Unwrap returns the underlying cause of the error, so errors.Is and errors.As see through it.
+func (e *AppErr) Unwrap() error {
+	return e.err
+}
+
+// Is reports whether the error matches target for errors.Is. A sentinel (see Sentinel) matches
+// only the errors derived from it; any other *AppErr matches the errors with the same code,
+// so errors.Is(err, apperr.New(apperr.ErrResourceNotFound, "")) works whatever the message.
+func (e *AppErr) Is(target error) bool {
+	t, ok := target.(*AppErr)
+	if !ok || t == nil {
+		return false
+	}
+	if t.isSentinel() {
+		return e.sentinel == t
+	}
+	return e.code == t.code
+}
+
+// clone returns a shallow copy of the error with its own context maps. Copies of a sentinel
+// record their origin at the caller of the builder that made them (WithContext, WithError...),
+// which must call clone directly.
+func (e *AppErr) clone() *AppErr {
+	c := *e
+	c.context = maps.Clone(e.context)
+	c.internal = maps.Clone(e.internal)
+	if e.isSentinel() {
+		c.stack = callers(e.code)
+	}
+	return &c
+}
+
+// setContext sets a public context value of an error that has not been shared yet,
+// redacting it if the key is sensitive.
+func (e *AppErr) setContext(key string, value any) {
 	if e.context == nil {
 		e.context = make(map[string]any)
 	}
-	e.context[key] = value
-	return e
+	e.context[key] = redact(key, value)
 }
 
-// WithError sets the underlying cause of the error.
-// It returns the modified AppErr for chaining.
-func (e *AppErr) WithError(err error) *AppErr {
-	e.err = err
-	return e
+// isSentinel reports whether the error was created by Sentinel.
+func (e *AppErr) isSentinel() bool {
+	return e.sentinel == e
 }
 
-// MarshalJSON implements the json.Marshaler interface.
+// MarshalJSON implements the json.Marshaler interface. Only the public context is rendered;
+// internal diagnostics are left out. Field violations are rendered as a top-level "errors" array instead of inside the context.
 func (e *AppErr) MarshalJSON() ([]byte, error) {
+	context := e.context
+	violations := e.GetFieldViolations()
+	if violations != nil {
+		context = make(map[string]any, len(e.context))
+		for k, v := range e.context {
+			if k != FieldViolationsKey {
+				context[k] = v
+			}
+		}
+	}
+
 	return json.Marshal(struct {
-		Code    string         `json:"code"`
-		Message string         `json:"message"`
-		Context map[string]any `json:"context,omitempty"`
+		Code    string           `json:"code"`
+		Message string           `json:"message"`
+		Context map[string]any   `json:"context,omitempty"`
+		Errors  []FieldViolation `json:"errors,omitempty"`
 	}{
 		Code:    string(e.code),
 		Message: e.message,
-		Context: e.context,
+		Context: context,
+		Errors:  violations,
 	})
 }
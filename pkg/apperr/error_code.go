@@ -41,31 +41,32 @@ const (
 	// ErrDeadlineExceeded indicates that the request timed out.
 	// Typically maps to HTTP 504 Gateway Timeout.
 	ErrDeadlineExceeded ErrorCode = "TIMEOUT"
-)
 
-// Description returns a human-readable description for the error code.
-// If the code is unknown, it returns the code itself as a string.
-func (c ErrorCode) Description() string {
-	switch c {
-	case ErrInvalidInput:
-		return "Invalid input provided"
-	case ErrUnauthorized:
-		return "Authentication required"
-	case ErrPermissionDenied:
-		return "Insufficient permissions"
-	case ErrResourceNotFound:
-		return "Resource not found"
-	case ErrConflict:
-		return "Resource already exists"
-	case ErrInternal:
-		return "Internal server error"
-	case ErrNotImplemented:
-		return "Feature not implemented"
-	case ErrUnavailable:
-		return "Service temporarily unavailable"
-	case ErrDeadlineExceeded:
-		return "Request timeout"
-	default:
-		return string(c)
-	}
-}
+	// ErrFailedPrecondition indicates that the system is not in the state required by the operation,
+	// e.g. deleting a non-empty folder. Typically maps to HTTP 412 Precondition Failed.
+	ErrFailedPrecondition ErrorCode = "FAILED_PRECONDITION"
+
+	// ErrResourceExhausted indicates that a quota or rate limit has been exceeded.
+	// Typically maps to HTTP 429 Too Many Requests.
+	ErrResourceExhausted ErrorCode = "RESOURCE_EXHAUSTED"
+
+	// ErrAborted indicates that the operation was aborted by a concurrency conflict,
+	// e.g. a failed optimistic lock or transaction. Typically maps to HTTP 409 Conflict.
+	ErrAborted ErrorCode = "ABORTED"
+
+	// ErrOutOfRange indicates that the operation was attempted past the valid range,
+	// e.g. reading past the end of a list. Typically maps to HTTP 400 Bad Request.
+	ErrOutOfRange ErrorCode = "OUT_OF_RANGE"
+
+	// ErrCancelled indicates that the operation was cancelled by the caller.
+	// Typically maps to HTTP 499 Client Closed Request.
+	ErrCancelled ErrorCode = "CANCELLED"
+
+	// ErrDataLoss indicates unrecoverable data loss or corruption.
+	// Typically maps to HTTP 500 Internal Server Error.
+	ErrDataLoss ErrorCode = "DATA_LOSS"
+
+	// ErrUnknown indicates an error that does not belong to any other category.
+	// Typically maps to HTTP 500 Internal Server Error.
+	ErrUnknown ErrorCode = "UNKNOWN"
+)
@@ -1,9 +1,17 @@
 package apperr
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/nochebuenadev/go-kit/pkg/i18n"
+	"google.golang.org/grpc/codes"
 )
 
 func TestNew(t *testing.T) {
@@ -61,6 +69,13 @@ func TestEnsureAppError(t *testing.T) {
 			t.Errorf("expected wrapped error, got %v", ensured.err)
 		}
 	})
+
+	t.Run("message in English", func(t *testing.T) {
+		msg := EnsureAppError(errors.New("boom")).GetMessage()
+		if got, _ := i18n.Default().Lookup(i18n.English, i18n.MessageKey(msg)); got != "An unexpected error occurred" {
+			t.Errorf("expected the message %q to be translated, got %q", msg, got)
+		}
+	})
 }
 
 func TestHelpers(t *testing.T) {
@@ -132,7 +147,8 @@ func TestErrorCode_Description(t *testing.T) {
 		{ErrInvalidInput, "Invalid input provided"},
 		{ErrUnauthorized, "Authentication required"},
 		{ErrInternal, "Internal server error"},
-		{ErrorCode("UNKNOWN"), "UNKNOWN"},
+		{ErrUnknown, "Unknown error"},
+		{ErrorCode("NOT_REGISTERED"), "NOT_REGISTERED"},
 	}
 
 	for _, tt := range tests {
@@ -142,6 +158,161 @@ func TestErrorCode_Description(t *testing.T) {
 	}
 }
 
+func TestErrorCode_Mapping(t *testing.T) {
+	tests := []struct {
+		code ErrorCode
+		http int
+		grpc codes.Code
+	}{
+		{ErrInvalidInput, http.StatusBadRequest, codes.InvalidArgument},
+		{ErrResourceNotFound, http.StatusNotFound, codes.NotFound},
+		{ErrConflict, http.StatusConflict, codes.AlreadyExists},
+		{ErrDeadlineExceeded, http.StatusGatewayTimeout, codes.DeadlineExceeded},
+		{ErrFailedPrecondition, http.StatusPreconditionFailed, codes.FailedPrecondition},
+		{ErrResourceExhausted, http.StatusTooManyRequests, codes.ResourceExhausted},
+		{ErrAborted, http.StatusConflict, codes.Aborted},
+		{ErrOutOfRange, http.StatusBadRequest, codes.OutOfRange},
+		{ErrCancelled, StatusClientClosedRequest, codes.Canceled},
+		{ErrDataLoss, http.StatusInternalServerError, codes.DataLoss},
+		{ErrUnknown, http.StatusInternalServerError, codes.Unknown},
+		{ErrorCode("NOT_REGISTERED"), http.StatusInternalServerError, codes.Unknown},
+	}
+
+	for _, tt := range tests {
+		if got := tt.code.HTTPStatus(); got != tt.http {
+			t.Errorf("%s: expected HTTP status %d, got %d", tt.code, tt.http, got)
+		}
+		if got := tt.code.GRPCCode(); got != tt.grpc {
+			t.Errorf("%s: expected gRPC code %s, got %s", tt.code, tt.grpc, got)
+		}
+	}
+}
+
+func TestFromHTTPStatus(t *testing.T) {
+	tests := []struct {
+		status   int
+		expected ErrorCode
+	}{
+		{http.StatusBadRequest, ErrInvalidInput},
+		{http.StatusPreconditionFailed, ErrFailedPrecondition},
+		{http.StatusTooManyRequests, ErrResourceExhausted},
+		{StatusClientClosedRequest, ErrCancelled},
+		{http.StatusRequestedRangeNotSatisfiable, ErrOutOfRange},
+		{http.StatusTeapot, ErrInvalidInput},
+		{http.StatusBadGateway, ErrInternal},
+	}
+
+	for _, tt := range tests {
+		if got := FromHTTPStatus(tt.status); got != tt.expected {
+			t.Errorf("status %d: expected %s, got %s", tt.status, tt.expected, got)
+		}
+	}
+}
+
+func TestFromGRPCCode(t *testing.T) {
+	for code := codes.Canceled; code <= codes.Unauthenticated; code++ {
+		if got := FromGRPCCode(code).GRPCCode(); got != code {
+			t.Errorf("gRPC code %s: round trip returned %s", code, got)
+		}
+	}
+
+	if got := FromGRPCCode(codes.OK); got != ErrUnknown {
+		t.Errorf("expected %s for codes.OK, got %s", ErrUnknown, got)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	// aliases are the statuses without a code of their own, mapped to the closest one.
+	aliases := map[int]bool{
+		http.StatusMethodNotAllowed:             true,
+		http.StatusRequestTimeout:               true,
+		http.StatusRequestEntityTooLarge:        true,
+		http.StatusRequestedRangeNotSatisfiable: true,
+		http.StatusUnprocessableEntity:          true,
+	}
+
+	for status, code := range fromHTTP {
+		if got := code.HTTPStatus(); got != status && !aliases[status] {
+			t.Errorf("status %d maps to %s, which maps back to %d", status, code, got)
+		}
+	}
+	for grpcCode, code := range fromGRPC {
+		if got := code.GRPCCode(); got != grpcCode {
+			t.Errorf("gRPC code %s maps to %s, which maps back to %s", grpcCode, code, got)
+		}
+	}
+	for code, info := range registry {
+		if got := FromHTTPStatus(info.httpStatus).HTTPStatus(); got != info.httpStatus {
+			t.Errorf("%s: status %d maps back to %d", code, info.httpStatus, got)
+		}
+		if got := FromGRPCCode(info.grpcCode); got != code {
+			t.Errorf("%s: gRPC code %s maps back to %s", code, info.grpcCode, got)
+		}
+	}
+}
+
+func TestRegister(t *testing.T) {
+	const ErrPaymentRequired ErrorCode = "PAYMENT_REQUIRED"
+
+	if err := Register(ErrPaymentRequired, http.StatusPaymentRequired, codes.FailedPrecondition, "Payment required"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := ErrPaymentRequired.HTTPStatus(); got != http.StatusPaymentRequired {
+		t.Errorf("expected HTTP status %d, got %d", http.StatusPaymentRequired, got)
+	}
+	if got := ErrPaymentRequired.GRPCCode(); got != codes.FailedPrecondition {
+		t.Errorf("expected gRPC code %s, got %s", codes.FailedPrecondition, got)
+	}
+	if got := ErrPaymentRequired.Description(); got != "Payment required" {
+		t.Errorf("expected description 'Payment required', got %s", got)
+	}
+	if got := FromHTTPStatus(http.StatusPaymentRequired); got != ErrPaymentRequired {
+		t.Errorf("expected %s for status 402, got %s", ErrPaymentRequired, got)
+	}
+	if got := FromGRPCCode(codes.FailedPrecondition); got != ErrFailedPrecondition {
+		t.Errorf("expected the canonical code to keep its gRPC code, got %s", got)
+	}
+
+	t.Run("Unclaimed gRPC code", func(t *testing.T) {
+		const ErrQuotaPending ErrorCode = "QUOTA_PENDING"
+		const custom = codes.Code(100)
+
+		if err := Register(ErrQuotaPending, http.StatusPaymentRequired, custom, ""); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := FromGRPCCode(custom); got != ErrQuotaPending {
+			t.Errorf("expected %s for the custom gRPC code, got %s", ErrQuotaPending, got)
+		}
+
+		if err := Register(ErrQuotaPending, http.StatusPaymentRequired, codes.Unknown, ""); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := FromGRPCCode(custom); got != ErrUnknown {
+			t.Errorf("expected the previous gRPC code to be released, got %s", got)
+		}
+	})
+
+	t.Run("Invalid registrations", func(t *testing.T) {
+		cases := []struct {
+			code   ErrorCode
+			status int
+		}{
+			{"", http.StatusBadRequest},
+			{ErrInternal, http.StatusTeapot},
+			{"CUSTOM", http.StatusOK},
+		}
+		for _, c := range cases {
+			if err := Register(c.code, c.status, codes.Unknown, ""); !errors.Is(err, ErrInvalidRegistration) {
+				t.Errorf("Register(%q, %d): expected ErrInvalidRegistration, got %v", c.code, c.status, err)
+			}
+		}
+		if ErrInternal.HTTPStatus() != http.StatusInternalServerError {
+			t.Error("expected canonical code to be left untouched")
+		}
+	})
+}
+
 func TestAppErr_Getters(t *testing.T) {
 	err := New(ErrInvalidInput, "bad input").WithContext("k", "v")
 
@@ -149,8 +320,327 @@ func TestAppErr_Getters(t *testing.T) {
 		t.Errorf("GetCode() failed, got %s", err.GetCode())
 	}
 
+	if err.GetMessage() != "bad input" {
+		t.Errorf("GetMessage() failed, got %s", err.GetMessage())
+	}
+
 	ctx := err.GetContext()
 	if ctx["k"] != "v" {
 		t.Errorf("GetContext() failed, got %v", ctx)
 	}
 }
+
+func TestAppErr_MessageKey(t *testing.T) {
+	params := map[string]string{"max": "10"}
+	base := New(ErrInvalidInput, "supera 10")
+	err := base.WithMessageKey("message.supera {max}", params)
+	params["max"] = "20"
+
+	key, got := err.GetMessageKey()
+	if key != "message.supera {max}" || got["max"] != "10" {
+		t.Errorf("expected the key and a copy of the params, got %q %v", key, got)
+	}
+	if key, _ := base.GetMessageKey(); key != "" {
+		t.Errorf("expected the original error to be left untouched, got %q", key)
+	}
+}
+
+func TestAppErr_FieldViolations(t *testing.T) {
+	err := InvalidInput("invalid data").
+		WithContext("form", "signup").
+		WithFieldViolations(FieldViolation{Field: "email", Tag: "required", Message: "email is required"}).
+		WithFieldViolations(FieldViolation{Field: "age", Tag: "min", Param: "18", Message: "age is too low"})
+
+	if got := err.GetFieldViolations(); len(got) != 2 || got[1].Field != "age" {
+		t.Fatalf("unexpected violations: %v", got)
+	}
+
+	data, mErr := json.Marshal(err)
+	if mErr != nil {
+		t.Fatalf("unexpected error: %v", mErr)
+	}
+
+	var body struct {
+		Context map[string]any   `json:"context"`
+		Errors  []FieldViolation `json:"errors"`
+	}
+	if mErr := json.Unmarshal(data, &body); mErr != nil {
+		t.Fatalf("failed to unmarshal: %v", mErr)
+	}
+	if len(body.Errors) != 2 || body.Errors[0].Field != "email" || body.Errors[1].Param != "18" {
+		t.Errorf("unexpected errors array: %v", body.Errors)
+	}
+	if _, ok := body.Context[FieldViolationsKey]; ok || body.Context["form"] != "signup" {
+		t.Errorf("unexpected context: %v", body.Context)
+	}
+
+	if New(ErrInternal, "boom").GetFieldViolations() != nil {
+		t.Error("expected no violations")
+	}
+}
+
+func TestAppErr_Origin(t *testing.T) {
+	defer SetStackMode(GetStackMode())
+	SetStackMode(StackNone)
+
+	for name, err := range map[string]*AppErr{
+		"New":            New(ErrInternal, "boom"),
+		"Wrap":           Wrap(ErrInternal, "boom", errors.New("cause")),
+		"Internal":       Internal("boom"),
+		"NotFound":       NotFound("missing"),
+		"EnsureAppError": EnsureAppError(errors.New("cause")),
+	} {
+		origin := err.Origin()
+		if !strings.HasSuffix(origin.Function, "apperr.TestAppErr_Origin") || !strings.HasSuffix(origin.File, "error_test.go") {
+			t.Errorf("%s: expected the origin in this test, got %s %s:%d", name, origin.Function, origin.File, origin.Line)
+		}
+		if len(err.StackTrace()) != 1 {
+			t.Errorf("%s: expected only the origin without stack mode, got %d frames", name, len(err.StackTrace()))
+		}
+	}
+}
+
+func TestAppErr_StackTrace(t *testing.T) {
+	defer SetStackMode(GetStackMode())
+
+	SetStackMode(StackInternal)
+	if n := len(Internal("boom").StackTrace()); n < 2 {
+		t.Errorf("expected the full stack for an internal error, got %d frames", n)
+	}
+	if n := len(NotFound("missing").StackTrace()); n != 1 {
+		t.Errorf("expected only the origin for a not found error, got %d frames", n)
+	}
+
+	SetStackMode(StackAll)
+	if n := len(NotFound("missing").StackTrace()); n < 2 {
+		t.Errorf("expected the full stack with StackAll, got %d frames", n)
+	}
+
+	if (&AppErr{}).StackTrace() != nil || (&AppErr{}).Origin().File != "" {
+		t.Error("expected no frames for an error built without constructor")
+	}
+}
+
+func TestAppErr_Format(t *testing.T) {
+	cause := New(ErrUnavailable, "db down")
+	err := Wrap(ErrInternal, "save failed", cause)
+
+	if got := fmt.Sprintf("%v", err); got != err.Error() {
+		t.Errorf("%%v: expected %q, got %q", err.Error(), got)
+	}
+	if got := fmt.Sprintf("%s", err); got != err.Error() {
+		t.Errorf("%%s: expected %q, got %q", err.Error(), got)
+	}
+	if got := fmt.Sprintf("%q", err); got != strconv.Quote(err.Error()) {
+		t.Errorf("%%q: unexpected %s", got)
+	}
+
+	detailed := fmt.Sprintf("%+v", err)
+	for _, want := range []string{
+		"INTERNAL_ERROR: save failed\n\t",
+		"apperr.TestAppErr_Format\n\t\t",
+		"error_test.go:",
+		"\nCaused by: SERVICE_UNAVAILABLE: db down\n\t",
+	} {
+		if !strings.Contains(detailed, want) {
+			t.Errorf("%%+v: expected %q in:\n%s", want, detailed)
+		}
+	}
+}
+
+func TestAppErr_Unwrap(t *testing.T) {
+	errNoRows := errors.New("no rows in result set")
+	err := NotFound("registro no encontrado").WithError(fmt.Errorf("query: %w", errNoRows))
+
+	if !errors.Is(err, errNoRows) {
+		t.Error("expected errors.Is to find the wrapped cause")
+	}
+
+	var target *AppErr
+	if !errors.As(fmt.Errorf("handler: %w", err), &target) || target.code != ErrResourceNotFound {
+		t.Errorf("expected errors.As to find the AppErr, got %v", target)
+	}
+}
+
+func TestAppErr_Is(t *testing.T) {
+	errUserNotFound := Sentinel(ErrResourceNotFound, "user not found")
+	errOrderNotFound := Sentinel(ErrResourceNotFound, "order not found")
+
+	derived := errUserNotFound.WithContext("user_id", 7)
+	wrapped := Wrap(ErrInternal, "lookup failed", fmt.Errorf("repo: %w", derived))
+
+	tests := []struct {
+		name     string
+		err      error
+		target   error
+		expected bool
+	}{
+		{"same code", NotFound("missing"), New(ErrResourceNotFound, ""), true},
+		{"different code", NotFound("missing"), New(ErrConflict, ""), false},
+		{"sentinel itself", errUserNotFound, errUserNotFound, true},
+		{"derived from sentinel", derived, errUserNotFound, true},
+		{"derived from sentinel in chain", wrapped, errUserNotFound, true},
+		{"code of a derived error", wrapped, New(ErrResourceNotFound, ""), true},
+		{"other sentinel", derived, errOrderNotFound, false},
+		{"plain error of the sentinel code", NotFound("user not found"), errUserNotFound, false},
+		{"non AppErr target", NotFound("missing"), errors.New("missing"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errors.Is(tt.err, tt.target); got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+
+	if !IsCode(wrapped, ErrResourceNotFound) || !IsCode(wrapped, ErrInternal) || IsCode(wrapped, ErrConflict) {
+		t.Error("unexpected IsCode result")
+	}
+}
+
+func TestAppErr_CopyOnWrite(t *testing.T) {
+	sentinel := Sentinel(ErrResourceNotFound, "user not found")
+
+	first := sentinel.WithContext("user_id", 1)
+	second := sentinel.WithContext("user_id", 2).WithError(errors.New("cause"))
+	withViolations := first.WithFieldViolations(FieldViolation{Field: "id", Tag: "required"})
+
+	if len(sentinel.GetContext()) != 0 || sentinel.err != nil {
+		t.Errorf("expected the sentinel to be untouched, got %v / %v", sentinel.GetContext(), sentinel.err)
+	}
+	if first.GetContext()["user_id"] != 1 || second.GetContext()["user_id"] != 2 {
+		t.Errorf("expected independent contexts, got %v and %v", first.GetContext(), second.GetContext())
+	}
+	if first.GetFieldViolations() != nil || len(withViolations.GetFieldViolations()) != 1 {
+		t.Error("expected WithFieldViolations to leave the receiver untouched")
+	}
+	if first.err != nil {
+		t.Error("expected WithError to leave the receiver untouched")
+	}
+
+	if origin := first.Origin(); !strings.HasSuffix(origin.Function, "apperr.TestAppErr_CopyOnWrite") {
+		t.Errorf("expected a sentinel copy to record where it was derived, got %s", origin.Function)
+	}
+}
+
+func TestJoin(t *testing.T) {
+	errA, errB := errors.New("a failed"), NotFound("b missing")
+
+	err := Join(ErrInternal, "tasks failed", errA, nil, errB)
+
+	var appErr *AppErr
+	if !errors.As(err, &appErr) || appErr.code != ErrInternal {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !errors.Is(err, errA) || !IsCode(err, ErrResourceNotFound) {
+		t.Error("expected errors.Is to match every cause")
+	}
+	if !strings.Contains(err.Error(), "a failed") || !strings.Contains(err.Error(), "b missing") {
+		t.Errorf("expected every cause in the message, got %s", err.Error())
+	}
+
+	if Join(ErrInternal, "nothing failed", nil, nil) != nil {
+		t.Error("expected nil when every error is nil")
+	}
+}
+
+func TestAppErr_PublicAndInternal(t *testing.T) {
+	err := New(ErrConflict, "user already exists").
+		WithPublic("field", "email").
+		WithInternal("constraint", "users_email_key")
+
+	if err.GetContext()["field"] != "email" || err.GetContext()["constraint"] != nil {
+		t.Errorf("unexpected public context: %v", err.GetContext())
+	}
+	if err.GetInternal()["constraint"] != "users_email_key" {
+		t.Errorf("unexpected internal diagnostics: %v", err.GetInternal())
+	}
+
+	data, _ := json.Marshal(err)
+	if strings.Contains(string(data), "users_email_key") || !strings.Contains(string(data), `"field":"email"`) {
+		t.Errorf("expected only public data in JSON, got %s", data)
+	}
+	if !strings.Contains(err.Detailed(), "users_email_key") {
+		t.Errorf("expected internal data in Detailed, got %s", err.Detailed())
+	}
+
+	copied := err.WithInternal("table", "users")
+	if len(err.GetInternal()) != 1 || len(copied.GetInternal()) != 2 {
+		t.Error("expected WithInternal to leave the receiver untouched")
+	}
+}
+
+func TestAppErr_Redaction(t *testing.T) {
+	err := New(ErrUnauthorized, "invalid credentials").
+		WithPublic("username", "john").
+		WithPublic("password", "hunter2").
+		WithInternal("X-Api-Key", "abc").
+		WithInternal("request", map[string]any{"access_token": "xyz", "path": "/login"})
+
+	if err.GetContext()["username"] != "john" || err.GetContext()["password"] != RedactedValue {
+		t.Errorf("unexpected public context: %v", err.GetContext())
+	}
+	if err.GetInternal()["X-Api-Key"] != RedactedValue {
+		t.Errorf("expected the api key to be redacted: %v", err.GetInternal())
+	}
+	request, _ := err.GetInternal()["request"].(map[string]any)
+	if request["access_token"] != RedactedValue || request["path"] != "/login" {
+		t.Errorf("expected nested values to be redacted: %v", request)
+	}
+
+	if IsSensitiveKey("document") {
+		t.Error("expected document not to be sensitive")
+	}
+	RegisterSensitiveKeys("Document")
+	if !IsSensitiveKey("customer_document") {
+		t.Error("expected a registered key to be sensitive")
+	}
+}
+
+func TestAppErr_Retryable(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		retryable bool
+		temporary bool
+	}{
+		{"plain error", New(ErrInvalidInput, "bad"), false, false},
+		{"retryable code", New(ErrUnavailable, "down"), true, false},
+		{"explicitly not retryable", New(ErrUnavailable, "down").WithRetryable(false), false, false},
+		{"explicitly retryable", New(ErrConflict, "deadlock").WithRetryable(true), true, false},
+		{"temporary", Internal("connection lost").WithTemporary(), true, true},
+		{"retry after", New(ErrResourceExhausted, "quota").WithRetryAfter(time.Second), true, false},
+		{"inherited from cause", Wrap(ErrInternal, "save failed", New(ErrAborted, "deadlock")), true, false},
+		{"wrapped by fmt", fmt.Errorf("saving: %w", Internal("lost").WithTemporary()), true, true},
+		{"standard error", errors.New("boom"), false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRetryable(tt.err); got != tt.retryable {
+				t.Errorf("IsRetryable: expected %v, got %v", tt.retryable, got)
+			}
+			if got := IsTemporary(tt.err); got != tt.temporary {
+				t.Errorf("IsTemporary: expected %v, got %v", tt.temporary, got)
+			}
+		})
+	}
+}
+
+func TestRetryAfterOf(t *testing.T) {
+	inner := New(ErrResourceExhausted, "rate limited").WithRetryAfter(30 * time.Second)
+
+	if d := RetryAfterOf(Wrap(ErrInternal, "call failed", inner)); d != 30*time.Second {
+		t.Errorf("expected the retry-after of the cause, got %s", d)
+	}
+	if d := RetryAfterOf(inner.WithRetryAfter(5 * time.Second)); d != 5*time.Second {
+		t.Errorf("expected the outermost retry-after, got %s", d)
+	}
+	if d := RetryAfterOf(errors.New("boom")); d != 0 {
+		t.Errorf("expected zero, got %s", d)
+	}
+	if inner.RetryAfter() != 30*time.Second {
+		t.Error("expected WithRetryAfter to leave the receiver untouched")
+	}
+}
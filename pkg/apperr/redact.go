@@ -0,0 +1,38 @@
+package apperr
+
+import "github.com/nochebuenadev/go-kit/internal/sensitive"
+
+// RedactedValue replaces the values of sensitive keys in the details of an error.
+const RedactedValue = sensitive.RedactedValue
+
+// RegisterSensitiveKeys adds keys whose values are redacted from the details of every error,
+// in addition to the built-in ones (password, secret, token, authorization, api_key, cookie...).
+// logz redacts the same keys from the log entries.
+func RegisterSensitiveKeys(keys ...string) {
+	sensitive.Register(keys...)
+}
+
+// IsSensitiveKey reports whether the values of key are redacted. Keys are split into words on
+// separators and camelCase, ignoring case, and match when their words spell a sensitive key, so
+// "X-Api-Key", "user_password" and "accessToken" are sensitive but "max_tokens" is not.
+func IsSensitiveKey(key string) bool {
+	return sensitive.IsKey(key)
+}
+
+// redact returns value, or RedactedValue if key is sensitive. The values of nested maps are
+// redacted as well.
+func redact(key string, value any) any {
+	if IsSensitiveKey(key) {
+		return RedactedValue
+	}
+
+	nested, ok := value.(map[string]any)
+	if !ok {
+		return value
+	}
+	redacted := make(map[string]any, len(nested))
+	for k, v := range nested {
+		redacted[k] = redact(k, v)
+	}
+	return redacted
+}
@@ -0,0 +1,219 @@
+package apperr
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+)
+
+// StatusClientClosedRequest is the non-standard HTTP status used when the client cancels the
+// request before the server answers (popularized by nginx). It is the HTTP status of ErrCancelled.
+const StatusClientClosedRequest = 499
+
+type (
+	// codeInfo holds the representations of an ErrorCode outside the application.
+	codeInfo struct {
+		// httpStatus is the HTTP status code returned for the error code.
+		httpStatus int
+		// grpcCode is the gRPC status code returned for the error code.
+		grpcCode codes.Code
+		// description is the human-readable description of the error code.
+		description string
+	}
+)
+
+var (
+	// ErrInvalidRegistration is returned by Register when the code or its HTTP status is not valid.
+	ErrInvalidRegistration = errors.New("apperr: registro de código inválido")
+
+	// registryMu guards registry, fromHTTP and fromGRPC.
+	registryMu sync.RWMutex
+
+	// registry maps every known error code to its HTTP status, gRPC code and description.
+	registry = map[ErrorCode]codeInfo{
+		ErrInvalidInput:       {http.StatusBadRequest, codes.InvalidArgument, "Invalid input provided"},
+		ErrUnauthorized:       {http.StatusUnauthorized, codes.Unauthenticated, "Authentication required"},
+		ErrPermissionDenied:   {http.StatusForbidden, codes.PermissionDenied, "Insufficient permissions"},
+		ErrResourceNotFound:   {http.StatusNotFound, codes.NotFound, "Resource not found"},
+		ErrConflict:           {http.StatusConflict, codes.AlreadyExists, "Resource already exists"},
+		ErrInternal:           {http.StatusInternalServerError, codes.Internal, "Internal server error"},
+		ErrNotImplemented:     {http.StatusNotImplemented, codes.Unimplemented, "Feature not implemented"},
+		ErrUnavailable:        {http.StatusServiceUnavailable, codes.Unavailable, "Service temporarily unavailable"},
+		ErrDeadlineExceeded:   {http.StatusGatewayTimeout, codes.DeadlineExceeded, "Request timeout"},
+		ErrFailedPrecondition: {http.StatusPreconditionFailed, codes.FailedPrecondition, "Operation rejected by the current state"},
+		ErrResourceExhausted:  {http.StatusTooManyRequests, codes.ResourceExhausted, "Resource exhausted"},
+		ErrAborted:            {http.StatusConflict, codes.Aborted, "Operation aborted"},
+		ErrOutOfRange:         {http.StatusBadRequest, codes.OutOfRange, "Value out of range"},
+		ErrCancelled:          {StatusClientClosedRequest, codes.Canceled, "Request cancelled"},
+		ErrDataLoss:           {http.StatusInternalServerError, codes.DataLoss, "Unrecoverable data loss"},
+		ErrUnknown:            {http.StatusInternalServerError, codes.Unknown, "Unknown error"},
+	}
+
+	// builtin marks the canonical codes, which cannot be overridden by Register.
+	builtin = func() map[ErrorCode]bool {
+		m := make(map[ErrorCode]bool, len(registry))
+		for code := range registry {
+			m[code] = true
+		}
+		return m
+	}()
+
+	// fromHTTP maps HTTP statuses to error codes. Statuses shared by several codes map to the
+	// most generic one, and a few statuses without a code of their own (405, 408, 413, 416 and
+	// 422) map to the closest one.
+	fromHTTP = map[int]ErrorCode{
+		http.StatusBadRequest:                   ErrInvalidInput,
+		http.StatusUnauthorized:                 ErrUnauthorized,
+		http.StatusForbidden:                    ErrPermissionDenied,
+		http.StatusNotFound:                     ErrResourceNotFound,
+		http.StatusMethodNotAllowed:             ErrNotImplemented,
+		http.StatusRequestTimeout:               ErrDeadlineExceeded,
+		http.StatusConflict:                     ErrConflict,
+		http.StatusPreconditionFailed:           ErrFailedPrecondition,
+		http.StatusRequestEntityTooLarge:        ErrInvalidInput,
+		http.StatusRequestedRangeNotSatisfiable: ErrOutOfRange,
+		http.StatusUnprocessableEntity:          ErrInvalidInput,
+		http.StatusTooManyRequests:              ErrResourceExhausted,
+		StatusClientClosedRequest:               ErrCancelled,
+		http.StatusInternalServerError:          ErrInternal,
+		http.StatusNotImplemented:               ErrNotImplemented,
+		http.StatusServiceUnavailable:           ErrUnavailable,
+		http.StatusGatewayTimeout:               ErrDeadlineExceeded,
+	}
+
+	// fromGRPC maps gRPC status codes to error codes. Every entry maps back to its gRPC code.
+	fromGRPC = map[codes.Code]ErrorCode{
+		codes.Canceled:           ErrCancelled,
+		codes.Unknown:            ErrUnknown,
+		codes.InvalidArgument:    ErrInvalidInput,
+		codes.DeadlineExceeded:   ErrDeadlineExceeded,
+		codes.NotFound:           ErrResourceNotFound,
+		codes.AlreadyExists:      ErrConflict,
+		codes.PermissionDenied:   ErrPermissionDenied,
+		codes.ResourceExhausted:  ErrResourceExhausted,
+		codes.FailedPrecondition: ErrFailedPrecondition,
+		codes.Aborted:            ErrAborted,
+		codes.OutOfRange:         ErrOutOfRange,
+		codes.Unimplemented:      ErrNotImplemented,
+		codes.Internal:           ErrInternal,
+		codes.Unavailable:        ErrUnavailable,
+		codes.DataLoss:           ErrDataLoss,
+		codes.Unauthenticated:    ErrUnauthorized,
+	}
+)
+
+// Register adds a custom error code with its HTTP status, gRPC code and description, so every
+// mapper in the kit handles it like a canonical one. Registering a custom code again replaces it.
+// If no other code claims httpStatus or grpcCode, FromHTTPStatus or FromGRPCCode return the
+// custom code for them; the canonical codes always keep their statuses.
+// It returns ErrInvalidRegistration if the code is empty or canonical, or if httpStatus is not
+// a 4xx or 5xx status.
+func Register(code ErrorCode, httpStatus int, grpcCode codes.Code, description string) error {
+	if code == "" {
+		return fmt.Errorf("%w: el código está vacío", ErrInvalidRegistration)
+	}
+	if builtin[code] {
+		return fmt.Errorf("%w: %s es un código predefinido", ErrInvalidRegistration, code)
+	}
+	if httpStatus < 400 || httpStatus > 599 {
+		return fmt.Errorf("%w: estado HTTP %d fuera de rango para %s", ErrInvalidRegistration, httpStatus, code)
+	}
+	if description == "" {
+		description = string(code)
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if prev, ok := registry[code]; ok {
+		if fromHTTP[prev.httpStatus] == code {
+			delete(fromHTTP, prev.httpStatus)
+		}
+		if fromGRPC[prev.grpcCode] == code {
+			delete(fromGRPC, prev.grpcCode)
+		}
+	}
+	registry[code] = codeInfo{httpStatus: httpStatus, grpcCode: grpcCode, description: description}
+	if _, ok := fromHTTP[httpStatus]; !ok {
+		fromHTTP[httpStatus] = code
+	}
+	if _, ok := fromGRPC[grpcCode]; !ok && grpcCode != codes.OK {
+		fromGRPC[grpcCode] = code
+	}
+	return nil
+}
+
+// lookup returns the registered information of the code.
+func lookup(code ErrorCode) (codeInfo, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	info, ok := registry[code]
+	return info, ok
+}
+
+// Description returns a human-readable description for the error code.
+// If the code is unknown, it returns the code itself as a string.
+func (c ErrorCode) Description() string {
+	if info, ok := lookup(c); ok {
+		return info.description
+	}
+	return string(c)
+}
+
+// HTTPStatus returns the HTTP status code for the error code.
+// If the code is unknown, it returns 500 Internal Server Error.
+func (c ErrorCode) HTTPStatus() int {
+	if info, ok := lookup(c); ok {
+		return info.httpStatus
+	}
+	return http.StatusInternalServerError
+}
+
+// GRPCCode returns the gRPC status code for the error code.
+// If the code is unknown, it returns codes.Unknown.
+func (c ErrorCode) GRPCCode() codes.Code {
+	if info, ok := lookup(c); ok {
+		return info.grpcCode
+	}
+	return codes.Unknown
+}
+
+// FromHTTPStatus returns the error code for an HTTP status. Unmapped statuses fall back to
+// ErrInternal for 5xx and to ErrInvalidInput otherwise.
+func FromHTTPStatus(status int) ErrorCode {
+	code, ok := LookupHTTPStatus(status)
+	switch {
+	case ok:
+		return code
+	case status >= 500:
+		return ErrInternal
+	default:
+		return ErrInvalidInput
+	}
+}
+
+// LookupHTTPStatus returns the error code mapped to an HTTP status, and false if the status is
+// not mapped, so callers can choose their own fallback.
+func LookupHTTPStatus(status int) (ErrorCode, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	code, ok := fromHTTP[status]
+	return code, ok
+}
+
+// FromGRPCCode returns the error code for a gRPC status code. codes.OK is not an error and,
+// like any unmapped value, returns ErrUnknown.
+func FromGRPCCode(c codes.Code) ErrorCode {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	if code, ok := fromGRPC[c]; ok {
+		return code
+	}
+	return ErrUnknown
+}
@@ -0,0 +1,115 @@
+package apperr
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// retryDefault leaves the retryability of an error to its code and cause.
+	retryDefault retryState = iota
+	// retryYes marks an error as retryable.
+	retryYes
+	// retryNo marks an error as not retryable.
+	retryNo
+)
+
+// retryState is the retryability set explicitly on an AppErr.
+type retryState uint8
+
+// retryableCodes are the codes that are retryable unless stated otherwise.
+var retryableCodes = map[ErrorCode]bool{
+	ErrUnavailable:      true,
+	ErrDeadlineExceeded: true,
+	ErrAborted:          true,
+}
+
+// WithRetryable returns a copy of the error explicitly marked as retryable or not, e.g. a
+// deadlock or a serialization failure that may succeed if the operation is run again.
+// The receiver is not modified.
+func (e *AppErr) WithRetryable(retryable bool) *AppErr {
+	c := e.clone()
+	c.retry = retryNo
+	if retryable {
+		c.retry = retryYes
+	}
+	return c
+}
+
+// WithTemporary returns a copy of the error marked as temporary: the condition is expected to
+// clear by itself (e.g. a lost connection). Temporary errors are retryable unless marked
+// otherwise with WithRetryable. The receiver is not modified.
+func (e *AppErr) WithTemporary() *AppErr {
+	c := e.clone()
+	c.temporary = true
+	return c
+}
+
+// WithRetryAfter returns a copy of the error that may be retried after d, e.g. from the
+// Retry-After header of a rate-limited response. mw.AppErrorHandler sends it back as a
+// Retry-After header. The receiver is not modified.
+func (e *AppErr) WithRetryAfter(d time.Duration) *AppErr {
+	c := e.clone()
+	c.retryAfter = d
+	return c
+}
+
+// Retryable reports whether the operation that failed may succeed if it is run again. It is the
+// value set with WithRetryable if any; otherwise errors that are temporary, have a retry-after
+// duration or have a retryable code (ErrUnavailable, ErrDeadlineExceeded, ErrAborted) are
+// retryable, and the remaining ones are as retryable as their cause.
+func (e *AppErr) Retryable() bool {
+	switch {
+	case e.retry != retryDefault:
+		return e.retry == retryYes
+	case e.temporary, e.retryAfter > 0, retryableCodes[e.code]:
+		return true
+	case e.err != nil:
+		return IsRetryable(e.err)
+	default:
+		return false
+	}
+}
+
+// Temporary reports whether the error was marked as temporary, or its cause is temporary.
+// Together with Retryable it makes AppErr classify like the errors of the net package.
+func (e *AppErr) Temporary() bool {
+	return e.temporary || (e.err != nil && IsTemporary(e.err))
+}
+
+// RetryAfter returns the duration to wait before retrying, or zero if it is unknown.
+func (e *AppErr) RetryAfter() time.Duration {
+	return e.retryAfter
+}
+
+// IsRetryable reports whether err may succeed if the operation is run again: the Retryable
+// value of the first AppErr in its chain, or whether it is temporary if there is none.
+func IsRetryable(err error) bool {
+	var appErr *AppErr
+	if errors.As(err, &appErr) {
+		return appErr.Retryable()
+	}
+	return IsTemporary(err)
+}
+
+// IsTemporary reports whether any error in err's chain reports itself as temporary with a
+// Temporary method, like AppErr and the errors of the net package.
+func IsTemporary(err error) bool {
+	var t interface{ Temporary() bool }
+	return errors.As(err, &t) && t.Temporary()
+}
+
+// RetryAfterOf returns the first retry-after duration found in err's chain, or zero.
+func RetryAfterOf(err error) time.Duration {
+	for err != nil {
+		var appErr *AppErr
+		if !errors.As(err, &appErr) {
+			return 0
+		}
+		if appErr.retryAfter > 0 {
+			return appErr.retryAfter
+		}
+		err = appErr.err
+	}
+	return 0
+}
@@ -0,0 +1,122 @@
+package apperr
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"runtime"
+	"strings"
+	"sync/atomic"
+)
+
+// EnvStackTrace is the environment variable that sets the initial StackMode
+// ("all", "internal" or "none"; defaults to "none").
+const EnvStackTrace = "APPERR_STACK_TRACE"
+
+const (
+	// StackNone records only the origin of the errors (the caller of New, Wrap, etc.).
+	StackNone StackMode = iota
+	// StackInternal records the full stack of the errors whose code maps to HTTP 500
+	// (ErrInternal, ErrUnknown, ErrDataLoss and custom codes registered with that status).
+	StackInternal
+	// StackAll records the full stack of every error.
+	StackAll
+)
+
+// maxStackDepth is the maximum number of frames recorded for an error.
+const maxStackDepth = 32
+
+// StackMode selects the errors whose full stack trace is recorded when they are created.
+type StackMode int32
+
+// stackMode is the current StackMode.
+var stackMode atomic.Int32
+
+func init() {
+	switch strings.ToLower(strings.TrimSpace(os.Getenv(EnvStackTrace))) {
+	case "all":
+		SetStackMode(StackAll)
+	case "internal":
+		SetStackMode(StackInternal)
+	}
+}
+
+// SetStackMode changes the errors whose full stack trace is recorded from now on.
+func SetStackMode(m StackMode) {
+	stackMode.Store(int32(m))
+}
+
+// GetStackMode returns the current StackMode.
+func GetStackMode() StackMode {
+	return StackMode(stackMode.Load())
+}
+
+// callers returns the program counters of the stack of the caller of an AppErr constructor:
+// the full stack if the stack mode requires it for the code, or only the origin otherwise.
+func callers(code ErrorCode) []uintptr {
+	depth := 1
+	switch GetStackMode() {
+	case StackAll:
+		depth = maxStackDepth
+	case StackInternal:
+		if code.HTTPStatus() == http.StatusInternalServerError {
+			depth = maxStackDepth
+		}
+	}
+
+	// Skip runtime.Callers, callers, newAppErr and the exported constructor.
+	pcs := make([]uintptr, depth)
+	return pcs[:runtime.Callers(4, pcs)]
+}
+
+// StackTrace returns the frames recorded when the error was created, starting at the caller
+// of the constructor. Only the origin is recorded unless the StackMode requires the full stack.
+func (e *AppErr) StackTrace() []runtime.Frame {
+	if len(e.stack) == 0 {
+		return nil
+	}
+
+	frames := runtime.CallersFrames(e.stack)
+	trace := make([]runtime.Frame, 0, len(e.stack))
+	for {
+		frame, more := frames.Next()
+		trace = append(trace, frame)
+		if !more {
+			break
+		}
+	}
+	return trace
+}
+
+// Origin returns the frame where the error was created (the caller of New, Wrap, etc.).
+// The frame is empty if the origin is unknown.
+func (e *AppErr) Origin() runtime.Frame {
+	if trace := e.StackTrace(); len(trace) > 0 {
+		return trace[0]
+	}
+	return runtime.Frame{}
+}
+
+// Format implements the fmt.Formatter interface. %s and %v print Error(), %q prints it quoted,
+// and %+v prints the code and message with the recorded frames, followed by the causal chain.
+func (e *AppErr) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			fmt.Fprintf(s, "%s: %s", e.code, e.message)
+			for _, f := range e.StackTrace() {
+				fmt.Fprintf(s, "\n\t%s\n\t\t%s:%d", f.Function, f.File, f.Line)
+			}
+			if e.err != nil {
+				fmt.Fprintf(s, "\nCaused by: %+v", e.err)
+			}
+			return
+		}
+		_, _ = io.WriteString(s, e.Error())
+	case 's':
+		_, _ = io.WriteString(s, e.Error())
+	case 'q':
+		fmt.Fprintf(s, "%q", e.Error())
+	}
+}
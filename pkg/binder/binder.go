@@ -0,0 +1,198 @@
+package binder
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nochebuenadev/go-kit/pkg/apperr"
+	"github.com/nochebuenadev/go-kit/pkg/check"
+	"github.com/nochebuenadev/go-kit/pkg/i18n"
+)
+
+// DefaultMaxBodySize is the maximum size of a request body accepted by Bind (1 MiB).
+const DefaultMaxBodySize int64 = 1 << 20
+
+type (
+	// Option configures a call to Bind.
+	Option func(*options)
+
+	// options holds the settings of a call to Bind.
+	options struct {
+		// maxBodySize is the maximum size of the body in bytes; 0 or less disables the limit.
+		maxBodySize int64
+		// allowUnknownFields accepts JSON fields that do not exist in the destination.
+		allowUnknownFields bool
+		// normalize enables the normalizers.
+		normalize bool
+		// validator validates the bound value; nil skips validation.
+		validator check.Validator
+	}
+)
+
+// WithMaxBodySize sets the maximum size of the body in bytes (defaults to DefaultMaxBodySize).
+// A value of 0 or less disables the limit.
+func WithMaxBodySize(n int64) Option {
+	return func(o *options) {
+		o.maxBodySize = n
+	}
+}
+
+// WithUnknownFields accepts JSON fields that do not exist in the destination, which are
+// rejected by default.
+func WithUnknownFields() Option {
+	return func(o *options) {
+		o.allowUnknownFields = true
+	}
+}
+
+// WithoutNormalization skips the normalizers.
+func WithoutNormalization() Option {
+	return func(o *options) {
+		o.normalize = false
+	}
+}
+
+// WithValidator sets the validator used after binding (defaults to check.Global).
+// A nil validator skips validation.
+func WithValidator(v check.Validator) Option {
+	return func(o *options) {
+		o.validator = v
+	}
+}
+
+// Bind creates a T and fills it from the request in one step:
+//  1. Path parameters ("param" tag), query parameters ("query" tag) and headers ("header" tag).
+//  2. The body: JSON is decoded rejecting unknown fields; forms and XML use the Echo binder.
+//  3. String fields with a "normalize" tag are normalized (see Normalize).
+//  4. The result is validated with check, passing the request context to the rules.
+//
+// Every failure is returned as an apperr.ErrInvalidInput error, with field violations when
+// they can be attributed to a field.
+func Bind[T any](c echo.Context, opts ...Option) (*T, error) {
+	o := &options{
+		maxBodySize: DefaultMaxBodySize,
+		normalize:   true,
+		validator:   check.Global(),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	v := new(T)
+	req := c.Request()
+	binder := &echo.DefaultBinder{}
+
+	if err := binder.BindPathParams(c, v); err != nil {
+		return nil, bindError("Parámetros de ruta no válidos", err)
+	}
+	if err := binder.BindQueryParams(c, v); err != nil {
+		return nil, bindError("Parámetros de consulta no válidos", err)
+	}
+	if err := binder.BindHeaders(c, v); err != nil {
+		return nil, bindError("Encabezados no válidos", err)
+	}
+
+	if o.maxBodySize > 0 {
+		if req.ContentLength > o.maxBodySize {
+			return nil, bodyTooLarge(o.maxBodySize)
+		}
+		req.Body = http.MaxBytesReader(c.Response(), req.Body, o.maxBodySize)
+	}
+	if err := bindBody(c, v, o); err != nil {
+		return nil, err
+	}
+
+	if o.normalize {
+		Normalize(v)
+	}
+
+	if o.validator != nil {
+		if err := o.validator.StructCtx(req.Context(), v); err != nil {
+			return nil, err
+		}
+	}
+	return v, nil
+}
+
+// bindBody decodes the request body into v. JSON bodies are decoded here to reject unknown
+// fields and report type errors per field; other media types use the Echo binder.
+func bindBody(c echo.Context, v any, o *options) error {
+	req := c.Request()
+	if req.ContentLength == 0 || req.Body == nil || req.Body == http.NoBody {
+		return nil
+	}
+
+	mediaType, _, _ := strings.Cut(req.Header.Get(echo.HeaderContentType), ";")
+	if strings.TrimSpace(mediaType) != echo.MIMEApplicationJSON {
+		if err := (&echo.DefaultBinder{}).BindBody(c, v); err != nil {
+			return bindError("Cuerpo de la petición no válido", err)
+		}
+		return nil
+	}
+
+	dec := json.NewDecoder(req.Body)
+	if !o.allowUnknownFields {
+		dec.DisallowUnknownFields()
+	}
+	if err := dec.Decode(v); err != nil {
+		return jsonError(err, o.maxBodySize)
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return apperr.InvalidInput("El cuerpo de la petición contiene datos después del JSON")
+	}
+	return nil
+}
+
+// jsonError converts a JSON decoding error into an apperr.ErrInvalidInput error.
+func jsonError(err error, maxBodySize int64) error {
+	var (
+		maxBytesErr *http.MaxBytesError
+		typeErr     *json.UnmarshalTypeError
+		syntaxErr   *json.SyntaxError
+	)
+
+	switch {
+	case errors.As(err, &maxBytesErr):
+		return bodyTooLarge(maxBodySize)
+	case errors.As(err, &typeErr):
+		return violation(typeErr.Field, "type", typeErr.Type.String(), err)
+	case errors.As(err, &syntaxErr), errors.Is(err, io.ErrUnexpectedEOF), errors.Is(err, io.EOF):
+		return apperr.Wrap(apperr.ErrInvalidInput, "El cuerpo de la petición no es un JSON válido", err)
+	}
+
+	if field, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+		return violation(strings.Trim(field, `"`), "unknown", "", err)
+	}
+	return apperr.Wrap(apperr.ErrInvalidInput, "Cuerpo de la petición no válido", err)
+}
+
+// violation returns an apperr.ErrInvalidInput error for a single field.
+func violation(field, tag, param string, err error) error {
+	msg := i18n.Default().TranslateTag(i18n.DefaultLocale, field, tag, param)
+	return apperr.Wrap(apperr.ErrInvalidInput, msg, err).
+		WithFieldViolations(apperr.FieldViolation{Field: field, Tag: tag, Param: param, Message: msg})
+}
+
+// bodyTooLarge returns the error for a body over the size limit. The message is rendered from
+// the catalogue and keeps its key, so it can be translated with the limit.
+func bodyTooLarge(maxBodySize int64) error {
+	key := i18n.MessageKey("El cuerpo de la petición supera el límite de {max_body_size} bytes")
+	params := map[string]string{"max_body_size": strconv.FormatInt(maxBodySize, 10)}
+	return apperr.New(apperr.ErrInvalidInput, i18n.Default().Translate(i18n.DefaultLocale, key, params)).
+		WithMessageKey(key, params).
+		WithContext("max_body_size", maxBodySize)
+}
+
+// bindError converts an error of the Echo binder into an apperr.ErrInvalidInput error.
+func bindError(msg string, err error) error {
+	var he *echo.HTTPError
+	if errors.As(err, &he) && he.Internal != nil {
+		err = he.Internal
+	}
+	return apperr.Wrap(apperr.ErrInvalidInput, msg, err)
+}
@@ -0,0 +1,235 @@
+package binder
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nochebuenadev/go-kit/pkg/apperr"
+	"github.com/nochebuenadev/go-kit/pkg/logz"
+	"github.com/nochebuenadev/go-kit/pkg/mw"
+)
+
+type createUserRequest struct {
+	ID       string   `param:"id"`
+	DryRun   bool     `query:"dry_run"`
+	TenantID string   `header:"X-Tenant-Id" validate:"required"`
+	Email    string   `json:"email" validate:"required,email" normalize:"email"`
+	Name     string   `json:"name" validate:"required,max=10" normalize:"trim"`
+	Code     string   `json:"code" normalize:"trim,upper"`
+	Raw      string   `json:"raw"`
+	Tags     []string `json:"tags" normalize:"trim"`
+	Age      int      `json:"age"`
+}
+
+// newContext builds an Echo context for a JSON request.
+func newContext(body string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/users/42?dry_run=true", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("X-Tenant-Id", "acme")
+	rec := httptest.NewRecorder()
+
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("42")
+	return c, rec
+}
+
+// assertInvalidInput checks that err is an ErrInvalidInput error and returns it.
+func assertInvalidInput(t *testing.T, err error) *apperr.AppErr {
+	t.Helper()
+
+	var appErr *apperr.AppErr
+	if !errors.As(err, &appErr) {
+		t.Fatalf("expected *apperr.AppErr, got %T (%v)", err, err)
+	}
+	if appErr.GetCode() != string(apperr.ErrInvalidInput) {
+		t.Errorf("expected code %s, got %s", apperr.ErrInvalidInput, appErr.GetCode())
+	}
+	return appErr
+}
+
+func TestBind(t *testing.T) {
+	c, _ := newContext(`{"email":"  Ana@Example.COM ","name":" Ana ","code":" ab1 ","raw":" x ","tags":[" a "]}`)
+
+	req, err := Bind[createUserRequest](c)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := createUserRequest{
+		ID:       "42",
+		DryRun:   true,
+		TenantID: "acme",
+		Email:    "ana@example.com",
+		Name:     "Ana",
+		Code:     "AB1",
+		Raw:      " x ",
+		Tags:     []string{"a"},
+	}
+	if req.ID != expected.ID || req.DryRun != expected.DryRun || req.TenantID != expected.TenantID ||
+		req.Email != expected.Email || req.Name != expected.Name || req.Code != expected.Code ||
+		req.Raw != expected.Raw || len(req.Tags) != 1 || req.Tags[0] != "a" {
+		t.Errorf("expected %+v, got %+v", expected, *req)
+	}
+}
+
+func TestBind_Errors(t *testing.T) {
+	tests := []struct {
+		name          string
+		body          string
+		opts          []Option
+		expectedField string
+		expectedTag   string
+	}{
+		{"unknown field", `{"email":"a@b.co","name":"Ana","admin":true}`, nil, "admin", "unknown"},
+		{"wrong type", `{"email":"a@b.co","name":"Ana","age":"ten"}`, nil, "age", "type"},
+		{"validation", `{"email":"not-an-email","name":"Ana"}`, nil, "email", "email"},
+		{"normalized before validation", `{"email":"a@b.co","name":"   "}`, nil, "name", "required"},
+		{"malformed JSON", `{"email":`, nil, "", ""},
+		{"trailing data", `{"email":"a@b.co","name":"Ana"} {}`, nil, "", ""},
+		{"body too large", `{"email":"a@b.co","name":"Ana"}`, []Option{WithMaxBodySize(8)}, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := newContext(tt.body)
+
+			_, err := Bind[createUserRequest](c, tt.opts...)
+			appErr := assertInvalidInput(t, err)
+
+			violations := appErr.GetFieldViolations()
+			if tt.expectedField == "" {
+				if len(violations) != 0 {
+					t.Errorf("expected no violations, got %v", violations)
+				}
+				return
+			}
+			if len(violations) != 1 || violations[0].Field != tt.expectedField || violations[0].Tag != tt.expectedTag {
+				t.Errorf("expected violation %s/%s, got %v", tt.expectedField, tt.expectedTag, violations)
+			}
+		})
+	}
+}
+
+func TestBind_Localized(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		opts     []Option
+		expected string
+	}{
+		{"malformed JSON", `{"email":`, nil, "The request body is not valid JSON"},
+		{"trailing data", `{"email":"a@b.co","name":"Ana"} {}`, nil, "The request body contains data after the JSON"},
+		{"body too large", `{"email":"a@b.co","name":"Ana"}`, []Option{WithMaxBodySize(8)}, "The request body exceeds the limit of 8 bytes"},
+	}
+
+	logz.MustInitWith(logz.WithSinks(logz.Sink{Writer: io.Discard}))
+	handler := mw.AppErrorHandler(logz.Global())
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, rec := newContext(tt.body)
+			c.Request().Header.Set("Accept-Language", "en")
+
+			_, err := Bind[createUserRequest](c, tt.opts...)
+			handler(err, c)
+
+			var body struct {
+				Message string `json:"message"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+			if body.Message != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, body.Message)
+			}
+		})
+	}
+}
+
+func TestBind_Options(t *testing.T) {
+	t.Run("unknown fields allowed", func(t *testing.T) {
+		c, _ := newContext(`{"email":"a@b.co","name":"Ana","admin":true}`)
+		if _, err := Bind[createUserRequest](c, WithUnknownFields()); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("without validation and normalization", func(t *testing.T) {
+		c, _ := newContext(`{"name":" Ana "}`)
+		c.Request().Header.Del("X-Tenant-Id")
+
+		req, err := Bind[createUserRequest](c, WithValidator(nil), WithoutNormalization())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if req.Name != " Ana " {
+			t.Errorf("expected the name untouched, got %q", req.Name)
+		}
+	})
+
+	t.Run("empty body", func(t *testing.T) {
+		c, _ := newContext("")
+		_, err := Bind[createUserRequest](c)
+
+		violations := assertInvalidInput(t, err).GetFieldViolations()
+		if len(violations) != 2 {
+			t.Errorf("expected the required email and name violations, got %v", violations)
+		}
+	})
+}
+
+func TestNormalize(t *testing.T) {
+	RegisterNormalizer("digits", func(s string) string {
+		return strings.Map(func(r rune) rune {
+			if r >= '0' && r <= '9' {
+				return r
+			}
+			return -1
+		}, s)
+	})
+
+	type address struct {
+		Street string `normalize:"trim"`
+	}
+	v := struct {
+		Phone    string `normalize:"digits"`
+		Password string
+		Address  *address
+		Skipped  address `normalize:"-"`
+		private  string
+	}{
+		Phone:    "+52 (55) 1234",
+		Password: " s3cret ",
+		Address:  &address{Street: " Main "},
+		Skipped:  address{Street: " Side "},
+		private:  " p ",
+	}
+
+	Normalize(&v)
+
+	if v.Phone != "52551234" || v.Password != " s3cret " || v.Address.Street != "Main" ||
+		v.Skipped.Street != " Side " || v.private != " p " {
+		t.Errorf("unexpected result: %+v", v)
+	}
+
+	t.Run("unknown normalizer", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected a panic for an unknown normalizer")
+			}
+		}()
+
+		var v struct {
+			Name string `normalize:"trim,titlecase"`
+		}
+		Normalize(&v)
+	})
+}
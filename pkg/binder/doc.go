@@ -0,0 +1,34 @@
+/*
+Package binder provides a generic Echo binder that binds, normalizes and validates a request
+in one step.
+
+Bind replaces the usual c.Bind(&req) followed by check.Global().Struct(req) in handlers, and
+returns every failure as an apperr.ErrInvalidInput error that mw.AppErrorHandler can render.
+
+Features:
+  - Binds path parameters, query parameters, headers and the body (JSON, forms and XML).
+  - Rejects unknown JSON fields and reports type errors per field (WithUnknownFields to accept them).
+  - Limits the body size (DefaultMaxBodySize, configurable with WithMaxBodySize).
+  - Normalizes the string fields that opt in with the "normalize" tag (trim, lower, upper,
+    email); other fields, such as passwords, are left untouched. RegisterNormalizer adds
+    custom normalizers.
+  - Validates with check, passing the request context to context-aware rules.
+
+Example usage:
+
+	type CreateUserRequest struct {
+		TenantID string `header:"X-Tenant-ID" validate:"required"`
+		Email    string `json:"email" validate:"required,email" normalize:"email"`
+		Name     string `json:"name" validate:"required,max=80" normalize:"trim"`
+		Code     string `json:"code" normalize:"trim,upper"`
+	}
+
+	e.POST("/users", func(c echo.Context) error {
+		req, err := binder.Bind[CreateUserRequest](c)
+		if err != nil {
+			return err
+		}
+		return c.JSON(http.StatusCreated, svc.Create(c.Request().Context(), req))
+	})
+*/
+package binder
@@ -0,0 +1,95 @@
+package binder
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+type (
+	// Normalizer transforms the value of a string field before validation.
+	Normalizer func(s string) string
+)
+
+var (
+	// normalizersMu guards normalizers.
+	normalizersMu sync.RWMutex
+
+	// normalizers maps the names usable in the "normalize" tag to their functions.
+	normalizers = map[string]Normalizer{
+		"trim":  strings.TrimSpace,
+		"lower": strings.ToLower,
+		"upper": strings.ToUpper,
+		"email": func(s string) string { return strings.ToLower(strings.TrimSpace(s)) },
+	}
+)
+
+// RegisterNormalizer adds a normalizer usable in the "normalize" tag, replacing any
+// normalizer with the same name.
+func RegisterNormalizer(name string, fn Normalizer) {
+	normalizersMu.Lock()
+	defer normalizersMu.Unlock()
+
+	normalizers[name] = fn
+}
+
+// Normalize applies the normalizers to the string fields of v, which must be a pointer.
+// Nested structs, pointers and slices are walked. Only the fields with a "normalize" tag are
+// changed, by the normalizers it lists (e.g. `normalize:"trim,upper"`); fields without the tag,
+// such as passwords, are left untouched. It panics if a tag names an unknown normalizer, which
+// is a programming error like an invalid validation tag.
+func Normalize(v any) {
+	normalizersMu.RLock()
+	defer normalizersMu.RUnlock()
+
+	normalizeValue(reflect.ValueOf(v), nil)
+}
+
+// normalizeValue applies fns to the strings reachable from v.
+func normalizeValue(v reflect.Value, fns []Normalizer) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			normalizeValue(v.Elem(), fns)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			normalizeValue(v.Index(i), fns)
+		}
+	case reflect.String:
+		if v.CanSet() && len(fns) > 0 {
+			s := v.String()
+			for _, fn := range fns {
+				s = fn(s)
+			}
+			v.SetString(s)
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			if f := t.Field(i); f.IsExported() && f.Tag.Get("normalize") != "-" {
+				normalizeValue(v.Field(i), fieldNormalizers(f))
+			}
+		}
+	}
+}
+
+// fieldNormalizers returns the normalizers listed in the "normalize" tag of a struct field,
+// or nil if it has none. It panics if a name is not registered.
+func fieldNormalizers(f reflect.StructField) []Normalizer {
+	tag := f.Tag.Get("normalize")
+	if tag == "" {
+		return nil
+	}
+
+	var fns []Normalizer
+	for _, name := range strings.Split(tag, ",") {
+		fn, ok := normalizers[strings.TrimSpace(name)]
+		if !ok {
+			panic(fmt.Sprintf("binder: normalizador desconocido %q en el campo %s", name, f.Name))
+		}
+		fns = append(fns, fn)
+	}
+	return fns
+}
@@ -1,19 +1,32 @@
 package check
 
 import (
+	"context"
 	"errors"
-	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/nochebuenadev/go-kit/pkg/apperr"
+	"github.com/nochebuenadev/go-kit/pkg/i18n"
 )
 
 type (
 	// Validator defines the interface for struct validation.
+	// Rules, struct rules and aliases must be registered before the validator is used.
 	Validator interface {
 		// Struct validates a struct and returns an apperr.AppErr if validation fails.
 		Struct(i interface{}) error
+		// StructCtx validates a struct like Struct, passing ctx to the context-aware rules.
+		StructCtx(ctx context.Context, i interface{}) error
+		// RegisterRule adds a custom tag validated by fn, with its message per locale.
+		RegisterRule(tag string, fn RuleFunc, messages map[i18n.Locale]string) error
+		// RegisterStructRule adds a rule that validates the given struct types as a whole.
+		RegisterStructRule(fn StructRuleFunc, types ...interface{})
+		// RegisterAlias adds a tag that stands for a list of tags, with its message per locale.
+		RegisterAlias(alias, tags string, messages map[i18n.Locale]string)
 	}
 
 	// customValidator is the concrete implementation of Validator using go-playground/validator.
@@ -30,12 +43,19 @@ var (
 	once sync.Once
 )
 
+// New creates a new, independent validator. Unlike Global it can be called several times,
+// e.g. to keep rules of a module apart.
+func New() Validator {
+	v := validator.New()
+	v.RegisterTagNameFunc(jsonFieldName)
+
+	return &customValidator{v: v}
+}
+
 // MustInit initializes the global validator instance once.
 func MustInit() {
 	once.Do(func() {
-		v := validator.New()
-
-		global = &customValidator{v: v}
+		global = New()
 	})
 }
 
@@ -50,7 +70,12 @@ func Global() Validator {
 // Struct implements the Validator interface.
 // It maps go-playground validation errors to our standard apperr.AppErr format.
 func (cv *customValidator) Struct(i interface{}) error {
-	err := cv.v.Struct(i)
+	return cv.StructCtx(context.Background(), i)
+}
+
+// StructCtx implements the Validator interface.
+func (cv *customValidator) StructCtx(ctx context.Context, i interface{}) error {
+	err := cv.v.StructCtx(ctx, i)
 	if err == nil {
 		return nil
 	}
@@ -62,30 +87,54 @@ func (cv *customValidator) Struct(i interface{}) error {
 
 	var validateErrs validator.ValidationErrors
 	if errors.As(err, &validateErrs) {
-		firstErr := validateErrs[0]
-		msg := getErrorMessage(firstErr)
+		violations := make([]apperr.FieldViolation, 0, len(validateErrs))
+		for _, fe := range validateErrs {
+			violations = append(violations, apperr.FieldViolation{
+				Field:   fieldPath(fe),
+				Tag:     fe.Tag(),
+				Param:   fe.Param(),
+				Message: getErrorMessage(fe),
+			})
+		}
 
+		msg := violations[0].Message
+		if len(violations) > 1 {
+			msg = i18n.Default().Translate(i18n.DefaultLocale, i18n.SummaryKey,
+				map[string]string{"count": strconv.Itoa(len(violations))})
+		}
 		return apperr.New(apperr.ErrInvalidInput, msg).
-			WithContext("field", firstErr.Field()).
-			WithContext("tag", firstErr.Tag()).
+			WithFieldViolations(violations...).
 			WithError(err)
 	}
 
 	return apperr.Wrap(apperr.ErrInvalidInput, "Datos de entrada no válidos", err)
 }
 
-// getErrorMessage maps validator.FieldError tags to human-readable error messages in Spanish.
-func getErrorMessage(err validator.FieldError) string {
-	switch err.Tag() {
-	case "required":
-		return fmt.Sprintf("El campo '%s' es obligatorio", err.Field())
-	case "email":
-		return fmt.Sprintf("El campo '%s' debe ser un correo electrónico válido", err.Field())
-	case "min":
-		return fmt.Sprintf("El campo '%s' es demasiado corto (mínimo %s)", err.Field(), err.Param())
-	case "max":
-		return fmt.Sprintf("El campo '%s' es demasiado largo (máximo %s)", err.Field(), err.Param())
+// jsonFieldName returns the name of the field in its json tag, so validation errors refer to the
+// fields as the client sends them. Fields without a json tag keep their Go name.
+func jsonFieldName(fld reflect.StructField) string {
+	name, _, _ := strings.Cut(fld.Tag.Get("json"), ",")
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return fld.Name
 	default:
-		return fmt.Sprintf("Error en el campo '%s': regla '%s' no cumplida", err.Field(), err.Tag())
+		return name
 	}
 }
+
+// fieldPath returns the path of the field without the name of the validated struct,
+// e.g. "address.zip_code" for User.address.zip_code.
+func fieldPath(fe validator.FieldError) string {
+	if _, path, ok := strings.Cut(fe.Namespace(), "."); ok {
+		return path
+	}
+	return fe.Field()
+}
+
+// getErrorMessage returns the message of a validation error from the i18n catalogue, in the
+// default locale. mw.AppErrorHandler translates it to the locale of the request.
+func getErrorMessage(err validator.FieldError) string {
+	return i18n.Default().TranslateTag(i18n.DefaultLocale, fieldPath(err), err.Tag(), err.Param())
+}
@@ -1,10 +1,14 @@
 package check
 
 import (
+	"context"
 	"strings"
 	"testing"
 
+	"github.com/go-playground/validator/v10"
 	"github.com/nochebuenadev/go-kit/pkg/apperr"
+	"github.com/nochebuenadev/go-kit/pkg/authz"
+	"github.com/nochebuenadev/go-kit/pkg/i18n"
 )
 
 type TestStruct struct {
@@ -114,3 +118,140 @@ func TestValidator_Struct(t *testing.T) {
 		})
 	}
 }
+
+func TestValidator_Struct_FieldViolations(t *testing.T) {
+	type Address struct {
+		ZipCode string `json:"zip_code" validate:"required"`
+	}
+	type Signup struct {
+		Email   string  `json:"email" validate:"required,email"`
+		Age     int     `json:"age,omitempty" validate:"min=18"`
+		Address Address `json:"address"`
+	}
+
+	err := Global().Struct(Signup{Email: "invalid-email", Age: 16})
+
+	ae, ok := err.(*apperr.AppErr)
+	if !ok {
+		t.Fatalf("expected *apperr.AppErr, got %T", err)
+	}
+	if ae.GetMessage() != "Los datos de entrada contienen 3 campos no válidos" {
+		t.Errorf("unexpected message: %s", ae.GetMessage())
+	}
+
+	expected := []apperr.FieldViolation{
+		{Field: "email", Tag: "email", Message: "El campo 'email' debe ser un correo electrónico válido"},
+		{Field: "age", Tag: "min", Param: "18", Message: "El campo 'age' es demasiado corto (mínimo 18)"},
+		{Field: "address.zip_code", Tag: "required", Message: "El campo 'address.zip_code' es obligatorio"},
+	}
+	violations := ae.GetFieldViolations()
+	if len(violations) != len(expected) {
+		t.Fatalf("expected %d violations, got %d: %v", len(expected), len(violations), violations)
+	}
+	for i := range expected {
+		if violations[i] != expected[i] {
+			t.Errorf("violation %d: expected %+v, got %+v", i, expected[i], violations[i])
+		}
+	}
+}
+
+// firstViolation returns the first field violation of a validation error.
+func firstViolation(t *testing.T, err error) apperr.FieldViolation {
+	t.Helper()
+
+	ae, ok := err.(*apperr.AppErr)
+	if !ok {
+		t.Fatalf("expected *apperr.AppErr, got %T (%v)", err, err)
+	}
+	violations := ae.GetFieldViolations()
+	if len(violations) == 0 {
+		t.Fatalf("expected field violations, got none")
+	}
+	return violations[0]
+}
+
+func TestValidator_RegisterRule(t *testing.T) {
+	type Member struct {
+		Email string `json:"email" validate:"required,unique_email"`
+	}
+
+	taken := map[string]map[string]bool{"acme": {"ana@acme.com": true}}
+
+	v := New()
+	err := v.RegisterRule("unique_email", func(ctx context.Context, fl validator.FieldLevel) bool {
+		tenantID, ok := TenantID(ctx)
+		return ok && !taken[tenantID][fl.Field().String()]
+	}, map[i18n.Locale]string{
+		i18n.Spanish: "El campo '{field}' ya está registrado",
+		i18n.English: "Field '{field}' is already registered",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx := authz.SetInContext(context.Background(), &authz.Identity{UID: "u1", TenantID: "acme"})
+
+	if err := v.StructCtx(ctx, Member{Email: "luis@acme.com"}); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+
+	violation := firstViolation(t, v.StructCtx(ctx, Member{Email: "ana@acme.com"}))
+	if violation.Tag != "unique_email" || violation.Message != "El campo 'email' ya está registrado" {
+		t.Errorf("unexpected violation: %+v", violation)
+	}
+	if got := i18n.Default().TranslateTag(i18n.English, violation.Field, violation.Tag, violation.Param); got != "Field 'email' is already registered" {
+		t.Errorf("unexpected English message: %s", got)
+	}
+
+	if err := v.Struct(Member{Email: "luis@acme.com"}); err == nil {
+		t.Error("expected an error without a tenant in the context")
+	}
+
+	if err := v.RegisterRule("", nil, nil); err == nil {
+		t.Error("expected an error for an empty tag")
+	}
+}
+
+func TestValidator_RegisterStructRule(t *testing.T) {
+	type Range struct {
+		From int `json:"from"`
+		To   int `json:"to"`
+	}
+
+	v := New()
+	v.RegisterStructRule(func(ctx context.Context, sl validator.StructLevel) {
+		r := sl.Current().Interface().(Range)
+		if r.To < r.From {
+			sl.ReportError(r.To, "to", "To", "gtefield", "from")
+		}
+	}, Range{})
+
+	if err := v.Struct(Range{From: 1, To: 2}); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+
+	violation := firstViolation(t, v.Struct(Range{From: 2, To: 1}))
+	if violation.Field != "to" || violation.Tag != "gtefield" || violation.Param != "from" {
+		t.Errorf("unexpected violation: %+v", violation)
+	}
+}
+
+func TestValidator_RegisterAlias(t *testing.T) {
+	type Account struct {
+		Username string `json:"username" validate:"username"`
+	}
+
+	v := New()
+	v.RegisterAlias("username", "required,alphanum,min=3,max=20", map[i18n.Locale]string{
+		i18n.Spanish: "El campo '{field}' debe tener entre 3 y 20 letras o números",
+	})
+
+	if err := v.Struct(Account{Username: "ana2024"}); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+
+	violation := firstViolation(t, v.Struct(Account{Username: "a!"}))
+	if violation.Tag != "username" || violation.Message != "El campo 'username' debe tener entre 3 y 20 letras o números" {
+		t.Errorf("unexpected violation: %+v", violation)
+	}
+}
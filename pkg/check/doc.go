@@ -5,17 +5,48 @@ It wraps the go-playground/validator package and maps its results to the applica
 standard apperr.AppErr type, providing consistent error reporting and human-readable
 messages in Spanish.
 
+Every failing field is reported at once: the returned AppErr carries one apperr.FieldViolation
+per field (path using the json tag names, rule, parameter and message), available through
+GetFieldViolations and rendered by mw.AppErrorHandler as an "errors" array.
+
+Validators can be extended before use:
+  - RegisterRule adds a custom tag with its messages per locale (added to i18n.Default).
+  - RegisterStructRule adds cross-field rules that validate a struct as a whole.
+  - RegisterAlias adds a tag standing for a list of tags, with its own messages.
+
+StructCtx passes the request context to the rules, which can use it to query a database; the
+tenant of the authenticated authz.Identity is available through TenantID.
+
 Example usage:
 
 	type User struct {
-		Email string `validate:"required,email"`
-		Age   int    `validate:"min=18"`
+		Email string `json:"email" validate:"required,email"`
+		Age   int    `json:"age" validate:"min=18"`
 	}
 
 	user := User{Email: "invalid-email", Age: 16}
 	err := check.Global().Struct(user)
 	if err != nil {
-		fmt.Println(err.Error()) // Output: El campo 'Email' debe ser un correo electrónico válido
+		fmt.Println(err.Error()) // Output: INVALID_ARGUMENT: Los datos de entrada contienen 2 campos no válidos
+
+		var appErr *apperr.AppErr
+		if errors.As(err, &appErr) {
+			for _, v := range appErr.GetFieldViolations() {
+				fmt.Println(v.Field, v.Message) // email El campo 'email' debe ser un correo electrónico válido
+			}
+		}
 	}
+
+	// Context-aware rule
+	v := check.Global()
+	_ = v.RegisterRule("unique_email", func(ctx context.Context, fl validator.FieldLevel) bool {
+		tenantID, _ := check.TenantID(ctx)
+		return !repo.EmailExists(ctx, tenantID, fl.Field().String())
+	}, map[i18n.Locale]string{
+		i18n.Spanish: "El campo '{field}' ya está registrado",
+		i18n.English: "Field '{field}' is already registered",
+	})
+
+	err = v.StructCtx(c.Request().Context(), user)
 */
 package check
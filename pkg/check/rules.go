@@ -0,0 +1,59 @@
+package check
+
+import (
+	"context"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/nochebuenadev/go-kit/pkg/authz"
+	"github.com/nochebuenadev/go-kit/pkg/i18n"
+)
+
+type (
+	// RuleFunc validates a field for a custom tag. ctx is the context passed to StructCtx
+	// (context.Background for Struct), so rules can query a database or read the tenant.
+	RuleFunc func(ctx context.Context, fl validator.FieldLevel) bool
+
+	// StructRuleFunc validates a struct as a whole, e.g. to compare several fields. Failures
+	// are reported with sl.ReportError and produce a field violation with the reported tag.
+	StructRuleFunc func(ctx context.Context, sl validator.StructLevel)
+)
+
+// RegisterRule implements the Validator interface. The messages are added to the i18n.Default
+// catalogue for the tag and may use the {field} and {param} placeholders.
+func (cv *customValidator) RegisterRule(tag string, fn RuleFunc, messages map[i18n.Locale]string) error {
+	if err := cv.v.RegisterValidationCtx(tag, validator.FuncCtx(fn)); err != nil {
+		return err
+	}
+
+	addMessages(tag, messages)
+	return nil
+}
+
+// RegisterStructRule implements the Validator interface.
+func (cv *customValidator) RegisterStructRule(fn StructRuleFunc, types ...interface{}) {
+	cv.v.RegisterStructValidationCtx(validator.StructLevelFuncCtx(fn), types...)
+}
+
+// RegisterAlias implements the Validator interface. Violations of the alias are reported with
+// the alias as tag, so its messages replace those of the tags it stands for.
+func (cv *customValidator) RegisterAlias(alias, tags string, messages map[i18n.Locale]string) {
+	cv.v.RegisterAlias(alias, tags)
+	addMessages(alias, messages)
+}
+
+// TenantID returns the tenant of the authenticated identity stored in ctx, for rules that are
+// scoped per tenant (e.g. an email that must be unique within the tenant).
+func TenantID(ctx context.Context) (string, bool) {
+	identity, ok := authz.FromContext(ctx)
+	if !ok || identity.TenantID == "" {
+		return "", false
+	}
+	return identity.TenantID, true
+}
+
+// addMessages adds the messages of a tag to the i18n.Default catalogue.
+func addMessages(tag string, messages map[i18n.Locale]string) {
+	for locale, msg := range messages {
+		i18n.Default().Add(locale, map[string]string{i18n.TagKey(tag): msg})
+	}
+}
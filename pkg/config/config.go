@@ -0,0 +1,393 @@
+package config
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nochebuenadev/go-kit/pkg/apperr"
+)
+
+type (
+	// Option configures how the environment is read by Load and Populate.
+	Option func(*options)
+
+	// options holds the settings applied by Option functions.
+	options struct {
+		// dotEnvFiles is the list of .env files to read, in order of precedence.
+		dotEnvFiles []string
+		// fileSecrets enables the resolution of NAME_FILE variables pointing to secret files.
+		fileSecrets bool
+		// prefix is prepended to every variable name.
+		prefix string
+		// lookup resolves a variable from the process environment.
+		lookup func(key string) (string, bool)
+	}
+
+	// loader walks a configuration struct and accumulates the problems found.
+	loader struct {
+		// opts are the options applied to this load.
+		opts *options
+		// dotEnv holds the variables read from .env files.
+		dotEnv map[string]string
+		// missing is the list of required variables that were not provided.
+		missing []string
+		// invalid maps each variable to the reason its value could not be used.
+		invalid map[string]string
+	}
+)
+
+const (
+	// defaultSeparator is the separator used for slices when envSeparator is not set.
+	defaultSeparator = ","
+	// fileSuffix is the suffix of variables that point to a file holding the actual value.
+	fileSuffix = "_FILE"
+)
+
+var (
+	// durationType is used to detect time.Duration fields, which are parsed with time.ParseDuration.
+	durationType = reflect.TypeOf(time.Duration(0))
+	// textUnmarshalerType is used to detect fields implementing encoding.TextUnmarshaler.
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// WithDotEnv reads variables from the given .env files. Variables already present in the
+// process environment take precedence, and earlier files take precedence over later ones.
+// Files that do not exist are ignored. With no arguments it reads ".env".
+func WithDotEnv(files ...string) Option {
+	return func(o *options) {
+		if len(files) == 0 {
+			files = []string{".env"}
+		}
+		o.dotEnvFiles = append(o.dotEnvFiles, files...)
+	}
+}
+
+// WithFileSecrets enables reading NAME_FILE variables: when NAME is not set but NAME_FILE is,
+// the contents of the referenced file (trimmed of surrounding whitespace) are used as the value.
+func WithFileSecrets() Option {
+	return func(o *options) {
+		o.fileSecrets = true
+	}
+}
+
+// WithPrefix prepends prefix to every variable name, so the same struct can be loaded several
+// times for different instances (e.g. "ANALYTICS_" reads ANALYTICS_PG_HOST instead of PG_HOST).
+func WithPrefix(prefix string) Option {
+	return func(o *options) {
+		o.prefix = prefix
+	}
+}
+
+// WithLookup replaces the function used to read the process environment (os.LookupEnv by default).
+func WithLookup(lookup func(key string) (string, bool)) Option {
+	return func(o *options) {
+		o.lookup = lookup
+	}
+}
+
+// Load creates a new T and populates it from the environment.
+// T must be a struct type annotated with env tags.
+func Load[T any](opts ...Option) (*T, error) {
+	cfg := new(T)
+	if err := Populate(cfg, opts...); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// MustLoad is like Load but panics if the configuration cannot be loaded.
+func MustLoad[T any](opts ...Option) *T {
+	cfg, err := Load[T](opts...)
+	if err != nil {
+		panic(fmt.Sprintf("config: no se pudo cargar la configuración: %v", err))
+	}
+	return cfg
+}
+
+// Loader returns a function that loads a new T with the given options on every call. It fits
+// the WithConfigLoader options of the kit components, so a launcher reload re-reads the
+// environment, .env files and secrets.
+func Loader[T any](opts ...Option) func() (*T, error) {
+	return func() (*T, error) {
+		return Load[T](opts...)
+	}
+}
+
+// Populate fills the struct pointed to by dst from the environment.
+// It returns an apperr.ErrInvalidInput error listing every missing or invalid variable.
+func Populate(dst any, opts ...Option) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return apperr.Internal("config: se esperaba un puntero a struct, se recibió %T", dst)
+	}
+
+	o := &options{lookup: os.LookupEnv}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	l := &loader{
+		opts:    o,
+		dotEnv:  make(map[string]string),
+		invalid: make(map[string]string),
+	}
+
+	if err := l.readDotEnv(); err != nil {
+		return err
+	}
+
+	l.walk(rv.Elem(), o.prefix)
+
+	return l.err()
+}
+
+// readDotEnv loads the configured .env files into the loader.
+func (l *loader) readDotEnv() error {
+	for _, file := range l.opts.dotEnvFiles {
+		vars, err := readDotEnvFile(file)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return apperr.Wrap(apperr.ErrInvalidInput, fmt.Sprintf("no se pudo leer el archivo %q", file), err).
+				WithContext("file", file)
+		}
+
+		for k, v := range vars {
+			if _, exists := l.dotEnv[k]; !exists {
+				l.dotEnv[k] = v
+			}
+		}
+	}
+	return nil
+}
+
+// walk populates every exported field of the struct value v, prepending prefix to variable names.
+func (l *loader) walk(v reflect.Value, prefix string) {
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+
+		fv := v.Field(i)
+		tag, hasTag := field.Tag.Lookup("env")
+
+		if !hasTag || tag == "" {
+			l.walkNested(fv, prefix+field.Tag.Get("envPrefix"))
+			continue
+		}
+
+		if !fv.CanSet() {
+			continue
+		}
+
+		name, required := parseTag(tag)
+		name = prefix + name
+
+		raw, found, err := l.value(name)
+		if err != nil {
+			l.invalid[name] = err.Error()
+			continue
+		}
+
+		if !found {
+			def, hasDefault := field.Tag.Lookup("envDefault")
+			if !hasDefault {
+				if required {
+					l.missing = append(l.missing, name)
+				}
+				continue
+			}
+			raw = def
+		}
+
+		sep := field.Tag.Get("envSeparator")
+		if sep == "" {
+			sep = defaultSeparator
+		}
+
+		if err := setValue(fv, raw, sep); err != nil {
+			l.invalid[name] = err.Error()
+		}
+	}
+}
+
+// walkNested descends into struct (or pointer to struct) fields without an env tag. A nil
+// pointer that cannot be set, such as an unexported embedded *struct, is skipped.
+func (l *loader) walkNested(fv reflect.Value, prefix string) {
+	switch {
+	case fv.Kind() == reflect.Struct:
+		l.walk(fv, prefix)
+	case fv.Kind() == reflect.Pointer && fv.Type().Elem().Kind() == reflect.Struct:
+		if fv.IsNil() {
+			if !fv.CanSet() {
+				return
+			}
+			fv.Set(reflect.New(fv.Type().Elem()))
+		}
+		l.walk(fv.Elem(), prefix)
+	}
+}
+
+// value resolves a variable from the process environment, NAME_FILE secrets and .env files,
+// in that order. Empty values are treated as not set.
+func (l *loader) value(name string) (string, bool, error) {
+	if v, ok := l.lookup(name); ok {
+		return v, true, nil
+	}
+
+	if l.opts.fileSecrets {
+		if path, ok := l.lookup(name + fileSuffix); ok {
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return "", false, fmt.Errorf("no se pudo leer el secreto %s%s: %w", name, fileSuffix, err)
+			}
+			return strings.TrimSpace(string(content)), true, nil
+		}
+	}
+
+	return "", false, nil
+}
+
+// lookup reads a non-empty variable from the process environment or, failing that, from .env files.
+func (l *loader) lookup(name string) (string, bool) {
+	if v, ok := l.opts.lookup(name); ok && v != "" {
+		return v, true
+	}
+	if v, ok := l.dotEnv[name]; ok && v != "" {
+		return v, true
+	}
+	return "", false
+}
+
+// err builds the aggregated error for the load, or returns nil if there were no problems.
+func (l *loader) err() error {
+	if len(l.missing) == 0 && len(l.invalid) == 0 {
+		return nil
+	}
+
+	var msg string
+	if len(l.invalid) == 0 {
+		msg = fmt.Sprintf("faltan variables de entorno requeridas: %s", strings.Join(l.missing, ", "))
+	} else {
+		names := make([]string, 0, len(l.invalid))
+		for name := range l.invalid {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		all := append(append([]string{}, l.missing...), names...)
+		msg = fmt.Sprintf("configuración de entorno no válida: %s", strings.Join(all, ", "))
+	}
+
+	err := apperr.New(apperr.ErrInvalidInput, msg)
+	if len(l.missing) > 0 {
+		err = err.WithContext("missing", l.missing)
+	}
+	if len(l.invalid) > 0 {
+		err = err.WithContext("invalid", l.invalid)
+	}
+	return err
+}
+
+// parseTag splits an env tag into the variable name and its required flag.
+func parseTag(tag string) (string, bool) {
+	parts := strings.Split(tag, ",")
+	required := false
+	for _, flag := range parts[1:] {
+		if strings.TrimSpace(flag) == "required" {
+			required = true
+		}
+	}
+	return strings.TrimSpace(parts[0]), required
+}
+
+// setValue parses raw according to the type of fv and assigns it.
+func setValue(fv reflect.Value, raw, sep string) error {
+	if fv.Kind() == reflect.Slice && !fv.Addr().Type().Implements(textUnmarshalerType) {
+		parts := strings.Split(raw, sep)
+		slice := reflect.MakeSlice(fv.Type(), 0, len(parts))
+		for _, part := range parts {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			elem := reflect.New(fv.Type().Elem()).Elem()
+			if err := setScalar(elem, part); err != nil {
+				return err
+			}
+			slice = reflect.Append(slice, elem)
+		}
+		fv.Set(slice)
+		return nil
+	}
+
+	return setScalar(fv, raw)
+}
+
+// setScalar parses raw into a single (non-slice) value.
+func setScalar(fv reflect.Value, raw string) error {
+	if fv.Kind() == reflect.Pointer {
+		ptr := reflect.New(fv.Type().Elem())
+		if err := setScalar(ptr.Elem(), raw); err != nil {
+			return err
+		}
+		fv.Set(ptr)
+		return nil
+	}
+
+	if fv.CanAddr() && fv.Addr().Type().Implements(textUnmarshalerType) {
+		return fv.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(raw))
+	}
+
+	if fv.Type() == durationType {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("duración no válida %q", raw)
+		}
+		fv.SetInt(int64(d))
+		return nil
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("booleano no válido %q", raw)
+		}
+		fv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, fv.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("entero no válido %q", raw)
+		}
+		fv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, fv.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("entero sin signo no válido %q", raw)
+		}
+		fv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, fv.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("número no válido %q", raw)
+		}
+		fv.SetFloat(f)
+	default:
+		return fmt.Errorf("tipo no soportado %s", fv.Type())
+	}
+
+	return nil
+}
@@ -0,0 +1,269 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nochebuenadev/go-kit/pkg/apperr"
+)
+
+type nestedConfig struct {
+	Timeout time.Duration `env:"TEST_TIMEOUT" envDefault:"5s"`
+}
+
+type testConfig struct {
+	Host    string   `env:"TEST_HOST,required"`
+	Port    int      `env:"TEST_PORT" envDefault:"8080"`
+	Debug   bool     `env:"TEST_DEBUG"`
+	Ratio   float64  `env:"TEST_RATIO" envDefault:"0.5"`
+	Retries uint     `env:"TEST_RETRIES" envDefault:"3"`
+	Addrs   []string `env:"TEST_ADDRS" envSeparator:";"`
+	Ports   []int    `env:"TEST_PORTS"`
+	Nested  nestedConfig
+	Ptr     *nestedConfig
+	ignored string
+}
+
+// envMap builds a lookup function backed by a map, isolating tests from the real environment.
+func envMap(vars map[string]string) Option {
+	return WithLookup(func(key string) (string, bool) {
+		v, ok := vars[key]
+		return v, ok
+	})
+}
+
+func TestLoad(t *testing.T) {
+	cfg, err := Load[testConfig](envMap(map[string]string{
+		"TEST_HOST":    "localhost",
+		"TEST_DEBUG":   "true",
+		"TEST_ADDRS":   "a:1; b:2",
+		"TEST_PORTS":   "1,2,3",
+		"TEST_TIMEOUT": "1m",
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.Host != "localhost" {
+		t.Errorf("expected host localhost, got %s", cfg.Host)
+	}
+	if cfg.Port != 8080 {
+		t.Errorf("expected default port 8080, got %d", cfg.Port)
+	}
+	if !cfg.Debug {
+		t.Error("expected debug to be true")
+	}
+	if cfg.Ratio != 0.5 || cfg.Retries != 3 {
+		t.Errorf("unexpected defaults: ratio=%v retries=%d", cfg.Ratio, cfg.Retries)
+	}
+	if !reflect.DeepEqual(cfg.Addrs, []string{"a:1", "b:2"}) {
+		t.Errorf("unexpected addrs: %v", cfg.Addrs)
+	}
+	if !reflect.DeepEqual(cfg.Ports, []int{1, 2, 3}) {
+		t.Errorf("unexpected ports: %v", cfg.Ports)
+	}
+	if cfg.Nested.Timeout != time.Minute {
+		t.Errorf("expected nested timeout 1m, got %v", cfg.Nested.Timeout)
+	}
+	if cfg.Ptr == nil || cfg.Ptr.Timeout != time.Minute {
+		t.Errorf("expected pointer struct to be allocated and populated, got %+v", cfg.Ptr)
+	}
+}
+
+func TestLoad_UnexportedEmbedded(t *testing.T) {
+	type embedded struct {
+		Name string `env:"TEST_NAME"`
+	}
+	type config struct {
+		*nestedConfig
+		embedded
+		Host string `env:"TEST_HOST"`
+	}
+
+	cfg, err := Load[config](envMap(map[string]string{"TEST_HOST": "localhost", "TEST_NAME": "api"}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.nestedConfig != nil {
+		t.Errorf("expected the unexported embedded pointer to be skipped, got %+v", cfg.nestedConfig)
+	}
+	if cfg.Host != "localhost" || cfg.Name != "api" {
+		t.Errorf("unexpected config: %+v", cfg)
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	type required struct {
+		A string `env:"REQ_A,required"`
+		B string `env:"REQ_B,required"`
+		C int    `env:"REQ_C"`
+	}
+
+	t.Run("missing required variables are aggregated", func(t *testing.T) {
+		_, err := Load[required](envMap(nil))
+		ae, ok := err.(*apperr.AppErr)
+		if !ok {
+			t.Fatalf("expected *apperr.AppErr, got %T", err)
+		}
+		if ae.GetCode() != string(apperr.ErrInvalidInput) {
+			t.Errorf("expected INVALID_ARGUMENT, got %s", ae.GetCode())
+		}
+		missing, _ := ae.GetContext()["missing"].([]string)
+		if !reflect.DeepEqual(missing, []string{"REQ_A", "REQ_B"}) {
+			t.Errorf("unexpected missing list: %v", missing)
+		}
+	})
+
+	t.Run("invalid values are reported", func(t *testing.T) {
+		_, err := Load[required](envMap(map[string]string{"REQ_A": "a", "REQ_B": "b", "REQ_C": "abc"}))
+		ae, ok := err.(*apperr.AppErr)
+		if !ok {
+			t.Fatalf("expected *apperr.AppErr, got %T", err)
+		}
+		invalid, _ := ae.GetContext()["invalid"].(map[string]string)
+		if _, ok := invalid["REQ_C"]; !ok {
+			t.Errorf("expected REQ_C to be reported as invalid, got %v", invalid)
+		}
+	})
+
+	t.Run("non struct pointer", func(t *testing.T) {
+		var s string
+		if err := Populate(&s); err == nil {
+			t.Error("expected error for non struct destination")
+		}
+	})
+}
+
+func TestLoad_DotEnvAndFileSecrets(t *testing.T) {
+	dir := t.TempDir()
+
+	envFile := filepath.Join(dir, ".env")
+	content := strings.Join([]string{
+		"# comment",
+		"export TEST_HOST=from-file",
+		`TEST_ADDRS="x:1;y:2" # inline comment`,
+		"TEST_PORT=9090 # inline comment",
+	}, "\n")
+	if err := os.WriteFile(envFile, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	secretFile := filepath.Join(dir, "secret")
+	if err := os.WriteFile(secretFile, []byte("s3cr3t\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	type withSecret struct {
+		testConfig
+		Password string `env:"TEST_PASSWORD,required"`
+	}
+
+	cfg, err := Load[withSecret](
+		envMap(map[string]string{
+			"TEST_PORT":          "7070",
+			"TEST_PASSWORD_FILE": secretFile,
+		}),
+		WithDotEnv(envFile, filepath.Join(dir, "missing.env")),
+		WithFileSecrets(),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.Host != "from-file" {
+		t.Errorf("expected host from .env, got %s", cfg.Host)
+	}
+	if cfg.Port != 7070 {
+		t.Errorf("expected process environment to take precedence, got %d", cfg.Port)
+	}
+	if !reflect.DeepEqual(cfg.Addrs, []string{"x:1", "y:2"}) {
+		t.Errorf("unexpected addrs: %v", cfg.Addrs)
+	}
+	if cfg.Password != "s3cr3t" {
+		t.Errorf("expected secret from file, got %q", cfg.Password)
+	}
+}
+
+func TestLoad_Prefix(t *testing.T) {
+	type dbConfig struct {
+		Host string `env:"DB_HOST,required"`
+	}
+	type appConfig struct {
+		Main      dbConfig
+		Analytics dbConfig `envPrefix:"ANALYTICS_"`
+	}
+
+	lookup := envMap(map[string]string{
+		"DB_HOST":           "main",
+		"ANALYTICS_DB_HOST": "analytics",
+		"SVC_DB_HOST":       "svc",
+	})
+
+	cfg, err := Load[dbConfig](lookup, WithPrefix("SVC_"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Host != "svc" {
+		t.Errorf("expected prefixed value, got %s", cfg.Host)
+	}
+
+	app, err := Load[appConfig](lookup)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if app.Main.Host != "main" || app.Analytics.Host != "analytics" {
+		t.Errorf("unexpected nested values: %+v", app)
+	}
+
+	_, err = Load[dbConfig](lookup, WithPrefix("OTHER_"))
+	ae, ok := err.(*apperr.AppErr)
+	if !ok {
+		t.Fatalf("expected *apperr.AppErr, got %T", err)
+	}
+	if missing, _ := ae.GetContext()["missing"].([]string); !reflect.DeepEqual(missing, []string{"OTHER_DB_HOST"}) {
+		t.Errorf("expected prefixed name in missing list, got %v", missing)
+	}
+}
+
+func TestParseDotEnv(t *testing.T) {
+	vars, err := parseDotEnv(strings.NewReader("A='lit # eral'\nB=\"line\\nbreak\"\nC=\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if vars["A"] != "lit # eral" || vars["B"] != "line\nbreak" || vars["C"] != "" {
+		t.Errorf("unexpected values: %#v", vars)
+	}
+
+	if _, err := parseDotEnv(strings.NewReader("INVALID")); err == nil {
+		t.Error("expected error for malformed line")
+	}
+}
+
+func TestMustLoad_Panics(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected MustLoad to panic")
+		}
+	}()
+	MustLoad[testConfig](envMap(nil))
+}
+
+func TestLoader(t *testing.T) {
+	vars := map[string]string{"TEST_HOST": "first"}
+	load := Loader[testConfig](envMap(vars))
+
+	cfg, err := load()
+	if err != nil || cfg.Host != "first" {
+		t.Fatalf("unexpected result: %+v, %v", cfg, err)
+	}
+
+	vars["TEST_HOST"] = "second"
+	cfg, err = load()
+	if err != nil || cfg.Host != "second" {
+		t.Errorf("expected the loader to read the environment again, got %+v, %v", cfg, err)
+	}
+}
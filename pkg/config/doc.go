@@ -0,0 +1,50 @@
+/*
+Package config populates configuration structs from environment variables.
+
+It understands the struct tags already used across the kit (pgutil.Config, vkutil.Config,
+server.Config, etc.), so every component configuration can be loaded with a single call
+instead of wiring a parser in each service.
+
+Supported tags:
+  - env:"NAME": the environment variable to read. Add ",required" to fail when it is missing.
+  - envDefault:"value": the value used when the variable is not set.
+  - envSeparator:",": the separator for slice fields (defaults to ",").
+  - envPrefix:"PREFIX_": on a nested struct field, prepended to the variables of that struct.
+
+Supported field types are strings, booleans, signed and unsigned integers, floats,
+time.Duration, types implementing encoding.TextUnmarshaler, slices of those types and
+nested structs (which are walked recursively).
+
+Every missing required variable and every unparsable value is aggregated into a single
+apperr.ErrInvalidInput error, so a misconfigured deployment reports all its problems at once.
+
+Because the kit's tags are fixed (PG_HOST, VK_ADDRS), WithPrefix and envPrefix allow the same
+struct to be loaded several times, e.g. to run two Postgres pools in the same service.
+
+Example usage:
+
+	pgCfg, err := config.Load[pgutil.Config]()
+	if err != nil {
+		logger.Fatal("config: configuración de postgres no válida", err)
+	}
+
+	// Reading a local .env file and Docker/Kubernetes secrets (PG_PASSWORD_FILE=/run/secrets/pg)
+	srvCfg := config.MustLoad[server.Config](
+		config.WithDotEnv(".env"),
+		config.WithFileSecrets(),
+	)
+
+	// A second pool reading ANALYTICS_PG_HOST, ANALYTICS_PG_USER, ...
+	analyticsCfg := config.MustLoad[pgutil.Config](config.WithPrefix("ANALYTICS_"))
+	analyticsDB := pgutil.New(logger, analyticsCfg)
+
+	// Reloading on SIGHUP: components re-run the loader in OnReload
+	wk := worker.New(logger, wkCfg, worker.WithConfigLoader(config.Loader[worker.Config](config.WithDotEnv())))
+
+	// The same through nested structs
+	type AppConfig struct {
+		Main      pgutil.Config
+		Analytics pgutil.Config `envPrefix:"ANALYTICS_"`
+	}
+*/
+package config
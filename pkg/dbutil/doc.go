@@ -3,5 +3,10 @@
 // underlying database engine (e.g., PostgreSQL, MySQL).
 //
 // The package also implements the Unit of Work pattern to manage transactions
-// across multiple operations in a consistent way.
+// across multiple operations in a consistent way. GetUnitOfWork returns a shared
+// instance, while NewUnitOfWork creates one per database when several pools coexist.
+//
+// The HandleError functions of pgutil and mysqlutil mark deadlocks, serialization failures
+// and connection errors as retryable, so a failed unit of work can be run again when
+// apperr.IsRetryable reports true.
 package dbutil
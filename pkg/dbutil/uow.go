@@ -35,18 +35,31 @@ var (
 	uowOnce sync.Once
 )
 
+// NewUnitOfWork creates a new, independent Unit of Work bound to the given provider.
+// Unlike GetUnitOfWork it can be called once per database when a service manages several pools.
+func NewUnitOfWork(logger logz.Logger, client Provider) UnitOfWork {
+	return &unitOfWork{
+		logger: logger,
+		client: client,
+	}
+}
+
 // GetUnitOfWork returns the singleton instance of the Unit of Work.
 func GetUnitOfWork(logger logz.Logger, client Provider) UnitOfWork {
 	uowOnce.Do(func() {
-		uowInstance = &unitOfWork{
-			logger: logger,
-			client: client,
-		}
+		uowInstance = NewUnitOfWork(logger, client)
 	})
 
 	return uowInstance
 }
 
+// Reset discards the singleton returned by GetUnitOfWork so the next call builds a new one.
+// It is intended for tests and must not be called concurrently with GetUnitOfWork.
+func Reset() {
+	uowInstance = nil
+	uowOnce = sync.Once{}
+}
+
 // TXFromContext retrieves the active transaction from the context.
 // It returns the transaction and true if found, nil and false otherwise.
 func TXFromContext(ctx context.Context) (Transaction, bool) {
@@ -57,7 +70,7 @@ func TXFromContext(ctx context.Context) (Transaction, bool) {
 // Do implements the UnitOfWork interface.
 // It handles the transaction lifecycle: begin, commit, and rollback.
 func (uow *unitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
-	uow.logger.Debug("dbutil: iniciando transacción de unidad de trabajo")
+	uow.logger.DebugCtx(ctx, "dbutil: iniciando transacción de unidad de trabajo")
 	tx, err := uow.client.Begin(ctx)
 	if err != nil {
 		return apperr.Internal("error al iniciar transacción").WithError(err)
@@ -65,7 +78,7 @@ func (uow *unitOfWork) Do(ctx context.Context, fn func(ctx context.Context) erro
 
 	defer func() {
 		if r := recover(); r != nil {
-			uow.logger.Warn("dbutil: pánico detectado, revirtiendo transacción", "panic", r)
+			uow.logger.WarnCtx(ctx, "dbutil: pánico detectado, revirtiendo transacción", "panic", r)
 			_ = tx.Rollback(ctx)
 			panic(r)
 		}
@@ -76,16 +89,16 @@ func (uow *unitOfWork) Do(ctx context.Context, fn func(ctx context.Context) erro
 	ctxWithTX := setTXInContext(ctx, tx)
 
 	if err := fn(ctxWithTX); err != nil {
-		uow.logger.Debug("dbutil: función falló, revirtiendo transacción", "error", err)
+		uow.logger.DebugCtx(ctx, "dbutil: función falló, revirtiendo transacción", "error", err)
 		return err
 	}
 
 	if err = tx.Commit(ctx); err != nil {
-		uow.logger.Error("dbutil: error al confirmar transacción", err)
+		uow.logger.ErrorCtx(ctx, "dbutil: error al confirmar transacción", err)
 		return apperr.Internal("error al confirmar transacción").WithError(err)
 	}
 
-	uow.logger.Debug("dbutil: transacción de unidad de trabajo confirmada correctamente")
+	uow.logger.DebugCtx(ctx, "dbutil: transacción de unidad de trabajo confirmada correctamente")
 	return nil
 }
 
@@ -63,14 +63,38 @@ type mockLogger struct {
 	logz.Logger
 }
 
-func (m *mockLogger) Debug(msg string, args ...any)               {}
-func (m *mockLogger) Info(msg string, args ...any)                {}
-func (m *mockLogger) Warn(msg string, args ...any)                {}
-func (m *mockLogger) Error(msg string, err error, args ...any)    {}
-func (m *mockLogger) LogError(msg string, err error, args ...any) {}
-func (m *mockLogger) Fatal(msg string, err error, args ...any)    {}
-func (m *mockLogger) With(args ...any) logz.Logger                { return m }
-func (m *mockLogger) WithContext(ctx context.Context) logz.Logger { return m }
+func (m *mockLogger) Debug(msg string, args ...any)                                       {}
+func (m *mockLogger) Info(msg string, args ...any)                                        {}
+func (m *mockLogger) Warn(msg string, args ...any)                                        {}
+func (m *mockLogger) Error(msg string, err error, args ...any)                            {}
+func (m *mockLogger) LogError(msg string, err error, args ...any)                         {}
+func (m *mockLogger) Fatal(msg string, err error, args ...any)                            {}
+func (m *mockLogger) DebugCtx(ctx context.Context, msg string, args ...any)               {}
+func (m *mockLogger) InfoCtx(ctx context.Context, msg string, args ...any)                {}
+func (m *mockLogger) WarnCtx(ctx context.Context, msg string, args ...any)                {}
+func (m *mockLogger) ErrorCtx(ctx context.Context, msg string, err error, args ...any)    {}
+func (m *mockLogger) LogErrorCtx(ctx context.Context, msg string, err error, args ...any) {}
+func (m *mockLogger) With(args ...any) logz.Logger                                        { return m }
+func (m *mockLogger) WithContext(ctx context.Context) logz.Logger                         { return m }
+func (m *mockLogger) Named(name string) logz.Logger                                       { return m }
+
+func TestNewUnitOfWork(t *testing.T) {
+	p1, p2 := &mockProvider{}, &mockProvider{}
+	u1 := NewUnitOfWork(&mockLogger{}, p1)
+	u2 := NewUnitOfWork(&mockLogger{}, p2)
+	if u1 == u2 {
+		t.Fatal("expected independent instances")
+	}
+	if u2.(*unitOfWork).client != p2 {
+		t.Error("expected each unit of work to keep its own provider")
+	}
+
+	Reset()
+	t.Cleanup(Reset)
+	if GetUnitOfWork(&mockLogger{}, p1).(*unitOfWork).client != p1 {
+		t.Error("expected a fresh singleton after Reset")
+	}
+}
 
 func TestUnitOfWork_Do(t *testing.T) {
 	logger := &mockLogger{}
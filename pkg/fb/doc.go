@@ -6,7 +6,7 @@ a singleton provider for accessing the Firebase App instance.
 
 Features:
 - Integration with application lifecycle.
-- Singleton provider for Firebase App.
+- Singleton provider for Firebase App, or independent instances via New.
 - Automated initialization via environment-based configuration.
 
 Example usage:
@@ -7,6 +7,7 @@ import (
 	firebase "firebase.google.com/go/v4"
 	"github.com/nochebuenadev/go-kit/pkg/launcher"
 	"github.com/nochebuenadev/go-kit/pkg/logz"
+	"google.golang.org/api/option"
 )
 
 type (
@@ -30,7 +31,14 @@ type (
 		logger logz.Logger
 		// app is the underlying firebase app instance.
 		app *firebase.App
+		// clientOpts are passed to firebase.NewApp (credentials, endpoints, etc.).
+		clientOpts []option.ClientOption
+		// name identifies the component in the launcher.
+		name string
 	}
+
+	// Option configures a Firebase component created with New.
+	Option func(*firebaseComponent)
 )
 
 var (
@@ -40,24 +48,57 @@ var (
 	fbOnce sync.Once
 )
 
+// WithClientOptions passes Google API client options to firebase.NewApp,
+// e.g. option.WithCredentialsFile to use a service account other than GOOGLE_APPLICATION_CREDENTIALS.
+func WithClientOptions(opts ...option.ClientOption) Option {
+	return func(f *firebaseComponent) {
+		f.clientOpts = append(f.clientOpts, opts...)
+	}
+}
+
+// WithName sets the name of the component in the launcher (defaults to "firebase").
+func WithName(name string) Option {
+	return func(f *firebaseComponent) {
+		f.name = name
+	}
+}
+
+// New creates a new, independent Firebase component. Unlike GetFirebase it can be called
+// several times, e.g. to talk to two Firebase projects from the same service.
+func New(logger logz.Logger, cfg *Config, opts ...Option) Component {
+	f := &firebaseComponent{
+		cfg:    cfg,
+		logger: logger,
+		name:   "firebase",
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
+}
+
 // GetFirebase returns the singleton instance of the Firebase component.
 func GetFirebase(logger logz.Logger, cfg *Config) Component {
 	fbOnce.Do(func() {
-		fbInstance = &firebaseComponent{
-			cfg:    cfg,
-			logger: logger,
-		}
+		fbInstance = New(logger, cfg)
 	})
 	return fbInstance
 }
 
+// Reset discards the singleton returned by GetFirebase so the next call builds a new one.
+// It is intended for tests and must not be called concurrently with GetFirebase.
+func Reset() {
+	fbInstance = nil
+	fbOnce = sync.Once{}
+}
+
 // OnInit implements the launcher.Component interface to initialize the Firebase App.
 func (f *firebaseComponent) OnInit() error {
 	f.logger.Info("fb: inicializando aplicación...", "project_id", f.cfg.ProjectID)
 
 	app, err := firebase.NewApp(context.Background(), &firebase.Config{
 		ProjectID: f.cfg.ProjectID,
-	})
+	}, f.clientOpts...)
 	if err != nil {
 		f.logger.Error("fb: error al crear la aplicación", err)
 		return err
@@ -83,3 +124,6 @@ func (f *firebaseComponent) OnStop() error {
 func (f *firebaseComponent) App() *firebase.App {
 	return f.app
 }
+
+// Name implements the launcher.Named interface.
+func (f *firebaseComponent) Name() string { return f.name }
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/nochebuenadev/go-kit/pkg/logz"
+	"google.golang.org/api/option"
 )
 
 type mockLogger struct {
@@ -25,6 +26,18 @@ func TestGetFirebase(t *testing.T) {
 	}
 }
 
+func TestNew(t *testing.T) {
+	cfg := &Config{ProjectID: "test-project"}
+	f1 := New(&mockLogger{}, cfg, WithClientOptions(option.WithoutAuthentication()))
+	f2 := New(&mockLogger{}, cfg)
+	if f1 == f2 {
+		t.Fatal("expected independent instances")
+	}
+	if len(f1.(*firebaseComponent).clientOpts) != 1 {
+		t.Error("expected client options to be stored")
+	}
+}
+
 func TestFirebaseComponent_App(t *testing.T) {
 	f := &firebaseComponent{app: nil}
 	if f.App() != nil {
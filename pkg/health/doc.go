@@ -10,6 +10,12 @@ Features:
 - Automatic latency measurement for each check.
 - Standardized JSON response format suitable for monitoring tools.
 - Integration with external components like databases (pgutil) and caches (vkutil).
+- Kubernetes-style /livez, /readyz and /startupz probes driven by the launcher lifecycle.
+
+Readyz and Startupz consult a Probe, usually the launcher, set with WithProbe. Readiness
+reports 503 while the application starts and as soon as the launcher begins its shutdown, so
+traffic is drained before the server stops (see launcher.WithShutdownDelay). Livez never runs
+the checks, so a failing dependency does not get the application restarted.
 
 Example usage:
 
@@ -18,5 +24,15 @@ Example usage:
 
 	// Register in Echo
 	e.GET("/health", healthHandler.HealthCheck)
+
+	// Or an independent handler with a custom timeout
+	internalHealth := health.NewHandler(logger, health.WithChecks(dbClient), health.WithTimeout(2*time.Second))
+
+	// Probes driven by the launcher lifecycle
+	app := launcher.New(logger, launcher.WithShutdownDelay(5*time.Second))
+	probes := health.NewHandler(logger, health.WithChecks(dbClient), health.WithProbe(app))
+	e.GET("/livez", probes.Livez)
+	e.GET("/readyz", probes.Readyz)
+	e.GET("/startupz", probes.Startupz)
 */
 package health
@@ -42,10 +42,24 @@ type (
 		Components map[string]ComponentStatus `json:"components"`
 	}
 
+	// Probe reports the lifecycle state of the application. launcher.Launcher implements it.
+	Probe interface {
+		// Started reports whether the application finished starting up.
+		Started() bool
+		// Ready reports whether the application should receive traffic.
+		Ready() bool
+	}
+
 	// Handler defines the interface for the health check HTTP handler.
 	Handler interface {
 		// HealthCheck is the Echo handler function for the health check endpoint.
 		HealthCheck(c echo.Context) error
+		// Livez is the Echo handler function for the liveness probe.
+		Livez(c echo.Context) error
+		// Readyz is the Echo handler function for the readiness probe.
+		Readyz(c echo.Context) error
+		// Startupz is the Echo handler function for the startup probe.
+		Startupz(c echo.Context) error
 	}
 
 	// handler is the concrete implementation of the health Handler.
@@ -54,7 +68,14 @@ type (
 		logger logz.Logger
 		// checks is the list of components to verify.
 		checks []Checkable
+		// timeout bounds the whole health check run.
+		timeout time.Duration
+		// probe reports the lifecycle state used by the readiness and startup probes.
+		probe Probe
 	}
+
+	// Option configures a handler created with NewHandler.
+	Option func(*handler)
 )
 
 const (
@@ -73,29 +94,100 @@ var (
 	handlerOnce sync.Once
 )
 
+// WithChecks adds components to verify during the health check.
+func WithChecks(checks ...Checkable) Option {
+	return func(h *handler) {
+		h.checks = append(h.checks, checks...)
+	}
+}
+
+// WithTimeout sets the global timeout applied to a health check run (defaults to 5s).
+func WithTimeout(d time.Duration) Option {
+	return func(h *handler) {
+		h.timeout = d
+	}
+}
+
+// WithProbe sets the lifecycle state used by Readyz and Startupz, usually the launcher.
+// Without a probe, the application is considered started and ready.
+func WithProbe(p Probe) Option {
+	return func(h *handler) {
+		h.probe = p
+	}
+}
+
+// NewHandler creates a new, independent health handler.
+// Unlike GetHandler it can be called several times, e.g. to expose different sets of checks.
+func NewHandler(logger logz.Logger, opts ...Option) Handler {
+	h := &handler{
+		logger:  logger,
+		timeout: 5 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
 // GetHandler returns the singleton instance of the health handler.
 // checks is a list of components to verify during the health check.
 func GetHandler(logger logz.Logger, checks ...Checkable) Handler {
 	handlerOnce.Do(func() {
-		handlerInstance = &handler{
-			logger: logger,
-			checks: checks,
-		}
+		handlerInstance = NewHandler(logger, WithChecks(checks...))
 	})
 
 	return handlerInstance
 }
 
+// Reset discards the singleton returned by GetHandler so the next call builds a new one.
+// It is intended for tests and must not be called concurrently with GetHandler.
+func Reset() {
+	handlerInstance = nil
+	handlerOnce = sync.Once{}
+}
+
 // HealthCheck executes all registered health checks concurrently and returns a summary response.
 // It leverages goroutines to perform checks in parallel, respecting a global timeout.
 // It returns HTTP 200 (OK) if all critical components are UP or DEGRADED,
 // and HTTP 503 (Service Unavailable) if any critical component is DOWN.
 func (h *handler) HealthCheck(c echo.Context) error {
-	// El timeout global sigue siendo bueno como "seguro de vida"
-	logger := h.logger.WithContext(c.Request().Context())
-	logger.Debug("health: petición de revisión de salud")
+	h.logger.DebugCtx(c.Request().Context(), "health: petición de revisión de salud")
+
+	httpStatus, resp := h.runChecks(c.Request().Context())
+	return c.JSON(httpStatus, resp)
+}
+
+// Livez reports that the process is alive and able to serve requests. It does not run the
+// checks, so a failing dependency does not make the orchestrator restart the application.
+func (h *handler) Livez(c echo.Context) error {
+	return c.JSON(http.StatusOK, Response{Status: "UP", Components: map[string]ComponentStatus{}})
+}
+
+// Readyz reports whether the application should receive traffic. It returns HTTP 503 while the
+// application is starting or shutting down, according to the probe, and otherwise behaves like
+// HealthCheck.
+func (h *handler) Readyz(c echo.Context) error {
+	if h.probe != nil && !h.probe.Ready() {
+		return c.JSON(http.StatusServiceUnavailable, Response{Status: "DOWN", Components: map[string]ComponentStatus{}})
+	}
 
-	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	httpStatus, resp := h.runChecks(c.Request().Context())
+	return c.JSON(httpStatus, resp)
+}
+
+// Startupz reports whether the application finished starting up. It returns HTTP 503 until the
+// probe reports the startup as completed.
+func (h *handler) Startupz(c echo.Context) error {
+	if h.probe != nil && !h.probe.Started() {
+		return c.JSON(http.StatusServiceUnavailable, Response{Status: "DOWN", Components: map[string]ComponentStatus{}})
+	}
+	return c.JSON(http.StatusOK, Response{Status: "UP", Components: map[string]ComponentStatus{}})
+}
+
+// runChecks executes all registered checks concurrently and builds the response with its HTTP status.
+func (h *handler) runChecks(parent context.Context) (int, Response) {
+	// El timeout global sigue siendo bueno como "seguro de vida"
+	ctx, cancel := context.WithTimeout(parent, h.timeout)
 	defer cancel()
 
 	overallStatus := "UP"
@@ -151,8 +243,8 @@ func (h *handler) HealthCheck(c echo.Context) error {
 		}
 	}
 
-	return c.JSON(httpStatus, Response{
+	return httpStatus, Response{
 		Status:     overallStatus,
 		Components: components,
-	})
+	}
 }
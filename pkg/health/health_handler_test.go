@@ -6,8 +6,8 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
-	"sync"
 	"testing"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/nochebuenadev/go-kit/pkg/logz"
@@ -15,14 +15,20 @@ import (
 
 type mockLogger struct{}
 
-func (m *mockLogger) Debug(msg string, args ...any)               {}
-func (m *mockLogger) Info(msg string, args ...any)                {}
-func (m *mockLogger) Warn(msg string, args ...any)                {}
-func (m *mockLogger) Error(msg string, err error, args ...any)    {}
-func (m *mockLogger) LogError(msg string, err error, args ...any) {}
-func (m *mockLogger) Fatal(msg string, err error, args ...any)    {}
-func (m *mockLogger) With(args ...any) logz.Logger                { return m }
-func (m *mockLogger) WithContext(ctx context.Context) logz.Logger { return m }
+func (m *mockLogger) Debug(msg string, args ...any)                                       {}
+func (m *mockLogger) Info(msg string, args ...any)                                        {}
+func (m *mockLogger) Warn(msg string, args ...any)                                        {}
+func (m *mockLogger) Error(msg string, err error, args ...any)                            {}
+func (m *mockLogger) LogError(msg string, err error, args ...any)                         {}
+func (m *mockLogger) Fatal(msg string, err error, args ...any)                            {}
+func (m *mockLogger) DebugCtx(ctx context.Context, msg string, args ...any)               {}
+func (m *mockLogger) InfoCtx(ctx context.Context, msg string, args ...any)                {}
+func (m *mockLogger) WarnCtx(ctx context.Context, msg string, args ...any)                {}
+func (m *mockLogger) ErrorCtx(ctx context.Context, msg string, err error, args ...any)    {}
+func (m *mockLogger) LogErrorCtx(ctx context.Context, msg string, err error, args ...any) {}
+func (m *mockLogger) With(args ...any) logz.Logger                                        { return m }
+func (m *mockLogger) WithContext(ctx context.Context) logz.Logger                         { return m }
+func (m *mockLogger) Named(name string) logz.Logger                                       { return m }
 
 type mockCheck struct {
 	name     string
@@ -76,8 +82,7 @@ func TestHealthHandler_HealthCheck(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Reset singleton for test
-			handlerInstance = nil
-			handlerOnce = sync.Once{}
+			Reset()
 
 			h := GetHandler(logger, tt.checks...)
 
@@ -104,3 +109,93 @@ func TestHealthHandler_HealthCheck(t *testing.T) {
 		})
 	}
 }
+
+func TestNewHandler(t *testing.T) {
+	e := echo.New()
+
+	slow := &slowCheck{mockCheck: mockCheck{name: "slow", priority: LevelCritical}}
+	h := NewHandler(&mockLogger{}, WithChecks(slow), WithTimeout(10*time.Millisecond))
+
+	req := httptest.NewRequest(http.MethodGet, "/health", nil)
+	rec := httptest.NewRecorder()
+	if err := h.HealthCheck(e.NewContext(req, rec)); err != nil {
+		t.Fatalf("HealthCheck failed: %v", err)
+	}
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected the timeout to mark the check as DOWN, got %d", rec.Code)
+	}
+}
+
+// slowCheck blocks until its context is done.
+type slowCheck struct {
+	mockCheck
+}
+
+func (s *slowCheck) HealthCheck(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+// mockProbe is a Probe with fixed answers.
+type mockProbe struct {
+	started bool
+	ready   bool
+}
+
+func (m *mockProbe) Started() bool { return m.started }
+func (m *mockProbe) Ready() bool   { return m.ready }
+
+func TestHandler_Probes(t *testing.T) {
+	e := echo.New()
+	failing := &mockCheck{name: "db", priority: LevelCritical, err: errors.New("conn failed")}
+	healthy := &mockCheck{name: "db", priority: LevelCritical}
+
+	tests := []struct {
+		name     string
+		probe    *mockProbe
+		check    Checkable
+		endpoint func(Handler) echo.HandlerFunc
+		expected int
+	}{
+		{"livez ignores checks", &mockProbe{}, failing, func(h Handler) echo.HandlerFunc { return h.Livez }, http.StatusOK},
+		{"readyz while starting", &mockProbe{}, healthy, func(h Handler) echo.HandlerFunc { return h.Readyz }, http.StatusServiceUnavailable},
+		{"readyz while stopping", &mockProbe{started: true}, healthy, func(h Handler) echo.HandlerFunc { return h.Readyz }, http.StatusServiceUnavailable},
+		{"readyz with failing check", &mockProbe{started: true, ready: true}, failing, func(h Handler) echo.HandlerFunc { return h.Readyz }, http.StatusServiceUnavailable},
+		{"readyz ready", &mockProbe{started: true, ready: true}, healthy, func(h Handler) echo.HandlerFunc { return h.Readyz }, http.StatusOK},
+		{"startupz while starting", &mockProbe{}, healthy, func(h Handler) echo.HandlerFunc { return h.Startupz }, http.StatusServiceUnavailable},
+		{"startupz while stopping", &mockProbe{started: true}, healthy, func(h Handler) echo.HandlerFunc { return h.Startupz }, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler(&mockLogger{}, WithChecks(tt.check), WithProbe(tt.probe))
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			if err := tt.endpoint(h)(e.NewContext(req, rec)); err != nil {
+				t.Fatalf("handler failed: %v", err)
+			}
+
+			if rec.Code != tt.expected {
+				t.Errorf("expected status %d, got %d", tt.expected, rec.Code)
+			}
+		})
+	}
+}
+
+func TestHandler_ProbesWithoutProbe(t *testing.T) {
+	e := echo.New()
+	h := NewHandler(&mockLogger{})
+
+	for _, endpoint := range []echo.HandlerFunc{h.Readyz, h.Startupz} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		rec := httptest.NewRecorder()
+		if err := endpoint(e.NewContext(req, rec)); err != nil {
+			t.Fatalf("handler failed: %v", err)
+		}
+		if rec.Code != http.StatusOK {
+			t.Errorf("expected status 200 without a probe, got %d", rec.Code)
+		}
+	}
+}
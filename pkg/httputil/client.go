@@ -6,9 +6,11 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net"
 	"net/http"
 	"net/http/httputil"
+	"strconv"
 	"sync"
 	"time"
 
@@ -24,6 +26,8 @@ type (
 		// Do executes an HTTP request and returns the response.
 		// It handles retries, circuit breaking, and logging automatically.
 		Do(req *http.Request) (*http.Response, error)
+		// OnReload reloads the retry and circuit breaker settings. It implements launcher.Reloadable.
+		OnReload(ctx context.Context) error
 	}
 
 	// httpClient is the concrete implementation of the Client interface.
@@ -32,7 +36,23 @@ type (
 		logger logz.Logger
 		cfg    *Config
 		cb     *gobreaker.CircuitBreaker
+		// name identifies the client's circuit breaker in logs.
+		name string
+		// mu guards cfg, which is replaced on reload.
+		mu sync.RWMutex
+		// loadConfig reloads the configuration on OnReload.
+		loadConfig func() (*Config, error)
 	}
+
+	// Option configures a client created with New.
+	Option func(*httpClient)
+)
+
+const (
+	// headerRetryAfter is the header with the time a server asks clients to wait before retrying.
+	headerRetryAfter = "Retry-After"
+	// maxDiscard is the most bytes read from a discarded body to reuse its connection.
+	maxDiscard = 64 << 10
 )
 
 var (
@@ -42,47 +62,130 @@ var (
 	clientOnce sync.Once
 )
 
-// GetClient returns the singleton instance of the resilient HTTP client.
-func GetClient(logger logz.Logger, cfg *Config) Client {
-	clientOnce.Do(func() {
-		if cfg == nil {
-			cfg = DefaultConfig()
-		}
+// WithName sets the name of the client's circuit breaker (defaults to "http-client").
+func WithName(name string) Option {
+	return func(c *httpClient) {
+		c.name = name
+	}
+}
 
-		cbSettings := gobreaker.Settings{
-			Name:        "http-client",
-			MaxRequests: 0,
-			Interval:    0,
-			Timeout:     cfg.CBTimeout,
-			ReadyToTrip: func(counts gobreaker.Counts) bool {
-				return counts.ConsecutiveFailures >= cfg.CBThreshold
-			},
-			OnStateChange: func(name string, from gobreaker.State, to gobreaker.State) {
-				logger.Warn("httputil: cambio de estado del circuit breaker", "name", name, "from", from.String(), "to", to.String())
-			},
-		}
+// WithHTTPClient replaces the underlying http.Client, e.g. to use a custom transport.
+// The client's own Timeout is kept as is.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *httpClient) {
+		c.client = client
+	}
+}
+
+// WithConfigLoader sets the function used by OnReload to read the configuration again,
+// e.g. config.Loader[httputil.Config](). MaxRetries, RetryDelay, MaxRetryAfter and CBThreshold
+// are applied at runtime; the timeouts require a restart.
+func WithConfigLoader(load func() (*Config, error)) Option {
+	return func(c *httpClient) {
+		c.loadConfig = load
+	}
+}
 
-		clientInstance = &httpClient{
-			client: &http.Client{
-				Timeout: cfg.Timeout,
-				Transport: &http.Transport{
-					DialContext: (&net.Dialer{
-						Timeout: cfg.DialTimeout,
-					}).DialContext,
-				},
+// New creates a new, independent resilient HTTP client with its own circuit breaker.
+// Unlike GetClient it can be called several times, e.g. one client per external API.
+// If cfg is nil, DefaultConfig is used.
+func New(logger logz.Logger, cfg *Config, opts ...Option) Client {
+	if cfg == nil {
+		cfg = DefaultConfig()
+	}
+
+	c := &httpClient{
+		client: &http.Client{
+			Timeout: cfg.Timeout,
+			Transport: &http.Transport{
+				DialContext: (&net.Dialer{
+					Timeout: cfg.DialTimeout,
+				}).DialContext,
 			},
-			logger: logger,
-			cfg:    cfg,
-			cb:     gobreaker.NewCircuitBreaker(cbSettings),
-		}
+		},
+		logger: logger,
+		cfg:    cfg,
+		name:   "http-client",
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	c.cb = gobreaker.NewCircuitBreaker(gobreaker.Settings{
+		Name:        c.name,
+		MaxRequests: 0,
+		Interval:    0,
+		Timeout:     cfg.CBTimeout,
+		ReadyToTrip: func(counts gobreaker.Counts) bool {
+			return counts.ConsecutiveFailures >= c.config().CBThreshold
+		},
+		OnStateChange: func(name string, from gobreaker.State, to gobreaker.State) {
+			logger.Warn("httputil: cambio de estado del circuit breaker", "name", name, "from", from.String(), "to", to.String())
+		},
+	})
+
+	return c
+}
+
+// GetClient returns the singleton instance of the resilient HTTP client.
+func GetClient(logger logz.Logger, cfg *Config) Client {
+	clientOnce.Do(func() {
+		clientInstance = New(logger, cfg)
 	})
 
 	return clientInstance
 }
 
+// Reset discards the singleton returned by GetClient so the next call builds a new one.
+// It is intended for tests and must not be called concurrently with GetClient.
+func Reset() {
+	clientInstance = nil
+	clientOnce = sync.Once{}
+}
+
+// OnReload implements Client. It reads the configuration again with the loader set by
+// WithConfigLoader. Without a loader it does nothing.
+func (c *httpClient) OnReload(ctx context.Context) error {
+	if c.loadConfig == nil {
+		return nil
+	}
+
+	cfg, err := c.loadConfig()
+	if err != nil {
+		return err
+	}
+
+	current := c.config()
+	if cfg.Timeout != current.Timeout || cfg.DialTimeout != current.DialTimeout || cfg.CBTimeout != current.CBTimeout {
+		c.logger.Warn("httputil: los cambios de timeout solo se aplican al reiniciar", "name", c.name)
+	}
+
+	next := *current
+	next.MaxRetries = cfg.MaxRetries
+	next.RetryDelay = cfg.RetryDelay
+	next.MaxRetryAfter = cfg.MaxRetryAfter
+	next.CBThreshold = cfg.CBThreshold
+
+	c.mu.Lock()
+	c.cfg = &next
+	c.mu.Unlock()
+
+	c.logger.Info("httputil: configuración recargada", "name", c.name,
+		"max_retries", next.MaxRetries, "retry_delay", next.RetryDelay.String(), "cb_threshold", next.CBThreshold)
+	return nil
+}
+
+// config returns the configuration in use.
+func (c *httpClient) config() *Config {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.cfg
+}
+
 // Do executes the request with retries and circuit breaking.
 func (c *httpClient) Do(req *http.Request) (*http.Response, error) {
 	var resp *http.Response
+	cfg := c.config()
 
 	// Execute with Circuit Breaker
 	result, err := c.cb.Execute(func() (any, error) {
@@ -96,31 +199,38 @@ func (c *httpClient) Do(req *http.Request) (*http.Response, error) {
 					req.Header.Set("X-Request-ID", requestID)
 				}
 
+				// The response of a failed attempt is discarded so its connection is reused
+				discardBody(resp)
+
 				start := time.Now()
 				resp, innerErr = c.client.Do(req)
 				latency := time.Since(start)
 
 				if innerErr != nil {
-					c.logger.Debug("httputil: error en petición de red", "err", innerErr, "url", req.URL.String())
+					c.logger.DebugCtx(req.Context(), "httputil: error en petición de red", "err", innerErr, "url", req.URL.String())
 					return innerErr
 				}
 
 				c.logMetadata(req, resp, latency)
 
-				// Only retry on 5xx errors
+				// Only retry on 5xx errors, and on 429 when the server says when to retry
 				if resp.StatusCode >= 500 {
-					return fmt.Errorf("server error: %d", resp.StatusCode)
+					return statusError(resp, fmt.Sprintf("server error: %d", resp.StatusCode))
+				}
+				if resp.StatusCode == http.StatusTooManyRequests && resp.Header.Get(headerRetryAfter) != "" {
+					return statusError(resp, "too many requests")
 				}
 
 				return nil
 			},
-			retry.Attempts(c.cfg.MaxRetries),
-			retry.Delay(c.cfg.RetryDelay),
-			retry.DelayType(retry.BackOffDelay), // Fixed: use DelayType for backoff strategy
+			retry.Context(req.Context()),
+			retry.Attempts(cfg.MaxRetries),
+			retry.Delay(cfg.RetryDelay),
+			retry.DelayType(retryDelay),
 			retry.LastErrorOnly(true),
 			retry.RetryIf(func(err error) bool {
-				// Retry on network errors or 5xx
-				return err != nil
+				// Retry on network errors or 5xx, unless the server asks to wait too long
+				return err != nil && apperr.RetryAfterOf(err) <= cfg.MaxRetryAfter
 			}),
 		)
 
@@ -129,8 +239,10 @@ func (c *httpClient) Do(req *http.Request) (*http.Response, error) {
 
 	if err != nil {
 		if errors.Is(err, gobreaker.ErrOpenState) {
-			return nil, apperr.Internal("servicio externo no disponible (circuito abierto)").WithError(err)
+			return nil, apperr.New(apperr.ErrUnavailable, "servicio externo no disponible (circuito abierto)").
+				WithRetryAfter(cfg.CBTimeout).WithError(err)
 		}
+		defer discardBody(resp)
 		return nil, c.mapError(err, resp)
 	}
 
@@ -147,7 +259,7 @@ func DoJSON[T any](ctx context.Context, client Client, req *http.Request) (*T, e
 	defer resp.Body.Close()
 
 	if resp.StatusCode >= 400 {
-		return nil, MapStatusToError(resp.StatusCode, "error en respuesta de API externa")
+		return nil, statusError(resp, "error en respuesta de API externa")
 	}
 
 	body, err := io.ReadAll(resp.Body)
@@ -163,54 +275,101 @@ func DoJSON[T any](ctx context.Context, client Client, req *http.Request) (*T, e
 	return &data, nil
 }
 
-// MapStatusToError converts an HTTP status code to its corresponding apperr.
+// MapStatusToError converts an HTTP status code to its corresponding apperr, using the code
+// registered in apperr for the status. Unmapped statuses return ErrInternal, since the upstream
+// service answered with something this client does not expect.
 func MapStatusToError(code int, msg string) error {
-	switch code {
-	case http.StatusNotFound:
-		return apperr.NotFound("%s", msg)
-	case http.StatusBadRequest:
-		return apperr.InvalidInput("%s", msg)
-	case http.StatusUnauthorized:
-		return apperr.New(apperr.ErrUnauthorized, msg)
-	case http.StatusForbidden:
-		return apperr.New(apperr.ErrPermissionDenied, msg)
-	case http.StatusConflict:
-		return apperr.New(apperr.ErrConflict, msg)
-	case http.StatusTooManyRequests:
-		return apperr.New(apperr.ErrUnavailable, msg)
-	default:
-		return apperr.Internal("%s", msg)
+	return apperr.New(codeForStatus(code), msg)
+}
+
+// statusError maps an error response to its apperr like MapStatusToError, keeping the wait
+// requested by its Retry-After header, if any.
+func statusError(resp *http.Response, msg string) *apperr.AppErr {
+	err := apperr.New(codeForStatus(resp.StatusCode), msg)
+	if d := parseRetryAfter(resp.Header.Get(headerRetryAfter)); d > 0 {
+		err = err.WithRetryAfter(d)
 	}
+	return err
+}
+
+// discardBody drains and closes the body of resp, if any, so the connection can be reused.
+// At most maxDiscard bytes are read; larger bodies just close the connection.
+func discardBody(resp *http.Response) {
+	if resp == nil || resp.Body == nil {
+		return
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDiscard))
+	_ = resp.Body.Close()
+}
+
+// codeForStatus returns the error code registered for an HTTP status, or ErrInternal.
+func codeForStatus(status int) apperr.ErrorCode {
+	if code, ok := apperr.LookupHTTPStatus(status); ok {
+		return code
+	}
+	return apperr.ErrInternal
+}
+
+// parseRetryAfter parses a Retry-After header, given either in seconds or as an HTTP date.
+// It returns zero if the header is missing, invalid or in the past.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0)
+	}
+	return 0
+}
+
+// retryDelay waits for the Retry-After requested by the server, or backs off exponentially
+// when there is none.
+func retryDelay(n uint, err error, config *retry.Config) time.Duration {
+	if d := apperr.RetryAfterOf(err); d > 0 {
+		return d
+	}
+	return retry.BackOffDelay(n, err, config)
 }
 
 // logMetadata logs request and response metadata.
 func (c *httpClient) logMetadata(req *http.Request, resp *http.Response, latency time.Duration) {
-	c.logger.Info("httputil: petición completada",
+	c.logger.InfoCtx(req.Context(), "httputil: petición completada",
 		"method", req.Method,
 		"url", req.URL.String(),
 		"status", resp.StatusCode,
 		"latency", latency.String(),
 	)
 
-	// Dump headers and body if debug mode is active
+	// Dump headers and body if debug mode is active, hiding credentials and personal data even
+	// when the logger does not redact its entries
+	if !logz.Enabled(req.Context(), c.logger, slog.LevelDebug) {
+		return
+	}
 	dump, err := httputil.DumpResponse(resp, true)
 	if err == nil {
-		c.logger.Debug("httputil: volcado de respuesta", "dump", string(dump))
+		c.logger.DebugCtx(req.Context(), "httputil: volcado de respuesta", "dump", logz.Redact(string(dump)))
 	}
 }
 
-// getRequestID retrieves the X-Request-ID from the context or logs if available.
+// getRequestID retrieves the X-Request-ID stored in the context with logz.WithRequestID, or
+// under the legacy "request_id" string key.
 func (c *httpClient) getRequestID(ctx context.Context) string {
+	if id := logz.GetRequestID(ctx); id != "" {
+		return id
+	}
 	if id, ok := ctx.Value("request_id").(string); ok {
 		return id
 	}
 	return ""
 }
 
-// mapError converts various errors to apperr.
+// mapError converts various errors to apperr. Network errors are temporary.
 func (c *httpClient) mapError(err error, resp *http.Response) error {
 	if resp != nil {
-		return MapStatusToError(resp.StatusCode, "error en petición HTTP")
+		return statusError(resp, "error en petición HTTP")
 	}
-	return apperr.Internal("error de red o timeout").WithError(err)
+	return apperr.Internal("error de red o timeout").WithTemporary().WithError(err)
 }
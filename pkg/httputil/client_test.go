@@ -2,13 +2,21 @@ package httputil
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/labstack/echo/v4"
+	"github.com/nochebuenadev/go-kit/pkg/apperr"
 	"github.com/nochebuenadev/go-kit/pkg/logz"
+	"github.com/nochebuenadev/go-kit/pkg/mw"
 	"github.com/sony/gobreaker"
 )
 
@@ -17,14 +25,19 @@ type mockLogger struct {
 	logz.Logger
 }
 
-func (m *mockLogger) Debug(msg string, args ...any)               {}
-func (m *mockLogger) Info(msg string, args ...any)                {}
-func (m *mockLogger) Warn(msg string, args ...any)                {}
-func (m *mockLogger) Error(msg string, err error, args ...any)    {}
-func (m *mockLogger) LogError(msg string, err error, args ...any) {}
-func (m *mockLogger) Fatal(msg string, err error, args ...any)    {}
-func (m *mockLogger) With(args ...any) logz.Logger                { return m }
-func (m *mockLogger) WithContext(ctx context.Context) logz.Logger { return m }
+func (m *mockLogger) Debug(msg string, args ...any)                                       {}
+func (m *mockLogger) Info(msg string, args ...any)                                        {}
+func (m *mockLogger) Warn(msg string, args ...any)                                        {}
+func (m *mockLogger) Error(msg string, err error, args ...any)                            {}
+func (m *mockLogger) LogError(msg string, err error, args ...any)                         {}
+func (m *mockLogger) Fatal(msg string, err error, args ...any)                            {}
+func (m *mockLogger) DebugCtx(ctx context.Context, msg string, args ...any)               {}
+func (m *mockLogger) InfoCtx(ctx context.Context, msg string, args ...any)                {}
+func (m *mockLogger) WarnCtx(ctx context.Context, msg string, args ...any)                {}
+func (m *mockLogger) ErrorCtx(ctx context.Context, msg string, err error, args ...any)    {}
+func (m *mockLogger) LogErrorCtx(ctx context.Context, msg string, err error, args ...any) {}
+func (m *mockLogger) With(args ...any) logz.Logger                                        { return m }
+func (m *mockLogger) WithContext(ctx context.Context) logz.Logger                         { return m }
 
 func TestHttpClient_Resilience(t *testing.T) {
 	logger := &mockLogger{}
@@ -89,12 +102,40 @@ func TestHttpClient_Resilience(t *testing.T) {
 		if err == nil {
 			t.Fatal("expected error from open circuit breaker, got nil")
 		}
-		if err.Error() != "INTERNAL_ERROR: servicio externo no disponible (circuito abierto) → circuit breaker is open" {
+		if err.Error() != "SERVICE_UNAVAILABLE: servicio externo no disponible (circuito abierto) → circuit breaker is open" {
 			t.Errorf("unexpected error message: %v", err)
 		}
+		if !apperr.IsCode(err, apperr.ErrUnavailable) || apperr.RetryAfterOf(err) != cfg.CBTimeout {
+			t.Errorf("expected ErrUnavailable retryable after %s, got %v", cfg.CBTimeout, err)
+		}
 	})
 }
 
+func TestNew(t *testing.T) {
+	logger := &mockLogger{}
+
+	c1 := New(logger, nil)
+	c2 := New(logger, &Config{MaxRetries: 1})
+	if c1 == c2 {
+		t.Fatal("expected independent instances")
+	}
+	if c1.(*httpClient).cfg.MaxRetries != DefaultConfig().MaxRetries {
+		t.Error("expected nil config to fall back to DefaultConfig")
+	}
+	if c1.(*httpClient).cb == c2.(*httpClient).cb {
+		t.Error("expected each client to have its own circuit breaker")
+	}
+
+	custom := &http.Client{}
+	c3 := New(logger, nil, WithName("payments"), WithHTTPClient(custom)).(*httpClient)
+	if c3.cb.Name() != "payments" {
+		t.Errorf("expected circuit breaker name payments, got %s", c3.cb.Name())
+	}
+	if c3.client != custom {
+		t.Error("expected the provided http.Client to be used")
+	}
+}
+
 func TestDoJSON(t *testing.T) {
 	logger := &mockLogger{}
 	cfg := DefaultConfig()
@@ -129,3 +170,270 @@ func TestDoJSON(t *testing.T) {
 		}
 	})
 }
+
+func TestHttpClient_OnReload(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	cfg := &Config{MaxRetries: 1, RetryDelay: time.Millisecond, CBThreshold: 100, CBTimeout: time.Minute, Timeout: time.Second}
+	reloaded := *cfg
+	reloaded.MaxRetries = 3
+
+	client := New(&mockLogger{}, cfg, WithConfigLoader(func() (*Config, error) {
+		return &reloaded, nil
+	}))
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	_, _ = client.Do(req)
+	if attempts != 1 {
+		t.Fatalf("expected 1 attempt before reload, got %d", attempts)
+	}
+
+	if err := client.OnReload(context.Background()); err != nil {
+		t.Fatalf("OnReload failed: %v", err)
+	}
+
+	attempts = 0
+	_, _ = client.Do(req)
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts after reload, got %d", attempts)
+	}
+	if cfg.MaxRetries != 1 {
+		t.Error("expected the original configuration not to be modified")
+	}
+}
+
+func TestHttpClient_RetryAfter(t *testing.T) {
+	cfg := &Config{MaxRetries: 3, RetryDelay: time.Millisecond, MaxRetryAfter: 2 * time.Second, CBThreshold: 100, CBTimeout: time.Minute, Timeout: 5 * time.Second}
+
+	t.Run("waits for the server", func(t *testing.T) {
+		attempts := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			if attempts == 1 {
+				w.Header().Set("Retry-After", "1")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		client := New(&mockLogger{}, cfg, WithName("test-retry-after"))
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+
+		start := time.Now()
+		resp, err := client.Do(req)
+		if err != nil || resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected success, got %v", err)
+		}
+		if attempts != 2 || time.Since(start) < time.Second {
+			t.Errorf("expected a second attempt after 1s, got %d attempts in %s", attempts, time.Since(start))
+		}
+	})
+
+	t.Run("gives up on long waits", func(t *testing.T) {
+		attempts := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			w.Header().Set("Retry-After", "120")
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		client := New(&mockLogger{}, cfg, WithName("test-retry-after-long"))
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+
+		_, err := client.Do(req)
+		if attempts != 1 {
+			t.Errorf("expected a single attempt, got %d", attempts)
+		}
+		if !apperr.IsCode(err, apperr.ErrUnavailable) || apperr.RetryAfterOf(err) != 2*time.Minute {
+			t.Errorf("expected ErrUnavailable retryable after 2m, got %v (%s)", err, apperr.RetryAfterOf(err))
+		}
+	})
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected time.Duration
+	}{
+		{"", 0},
+		{"30", 30 * time.Second},
+		{"-5", 0},
+		{"soon", 0},
+		{time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), 0},
+	}
+
+	for _, tt := range tests {
+		if got := parseRetryAfter(tt.value); got != tt.expected {
+			t.Errorf("%q: expected %s, got %s", tt.value, tt.expected, got)
+		}
+	}
+
+	future := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+	if got := parseRetryAfter(future); got < 59*time.Minute || got > time.Hour {
+		t.Errorf("expected about 1h for an HTTP date, got %s", got)
+	}
+}
+
+func TestMapStatusToError(t *testing.T) {
+	tests := []struct {
+		status   int
+		expected apperr.ErrorCode
+	}{
+		{http.StatusNotFound, apperr.ErrResourceNotFound},
+		{http.StatusPreconditionFailed, apperr.ErrFailedPrecondition},
+		{http.StatusTooManyRequests, apperr.ErrResourceExhausted},
+		{http.StatusTeapot, apperr.ErrInternal},
+		{http.StatusBadGateway, apperr.ErrInternal},
+	}
+
+	for _, tt := range tests {
+		if err := MapStatusToError(tt.status, "upstream"); !apperr.IsCode(err, tt.expected) {
+			t.Errorf("status %d: expected %s, got %v", tt.status, tt.expected, err)
+		}
+	}
+}
+
+// closeTracker is a transport that counts the response bodies still open.
+type closeTracker struct {
+	mu   sync.Mutex
+	open int
+}
+
+// trackedBody decrements the open bodies of its tracker when closed.
+type trackedBody struct {
+	io.ReadCloser
+	tracker *closeTracker
+	once    sync.Once
+}
+
+func (b *trackedBody) Close() error {
+	b.once.Do(func() {
+		b.tracker.mu.Lock()
+		b.tracker.open--
+		b.tracker.mu.Unlock()
+	})
+	return b.ReadCloser.Close()
+}
+
+func (t *closeTracker) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := http.DefaultTransport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	t.mu.Lock()
+	t.open++
+	t.mu.Unlock()
+	resp.Body = &trackedBody{ReadCloser: resp.Body, tracker: t}
+	return resp, nil
+}
+
+func TestHttpClient_ClosesRetriedBodies(t *testing.T) {
+	cfg := &Config{MaxRetries: 3, RetryDelay: time.Millisecond, MaxRetryAfter: time.Second, CBThreshold: 100, CBTimeout: time.Minute, Timeout: 5 * time.Second}
+
+	// Below DEBUG the responses are not dumped, which would read and replace their bodies
+	logz.MustInitWith(logz.WithSinks(logz.Sink{Writer: io.Discard}))
+	logz.SetNamedLevel("httputil-close-test", slog.LevelInfo, 0)
+	logger := logz.Global().Named("httputil-close-test")
+
+	t.Run("success after retries", func(t *testing.T) {
+		attempts := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			if attempts < 3 {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+				fmt.Fprint(w, "slow down")
+				return
+			}
+			fmt.Fprint(w, "ok")
+		}))
+		defer server.Close()
+
+		tracker := &closeTracker{}
+		client := New(logger, cfg, WithName("test-close-success"), WithHTTPClient(&http.Client{Transport: tracker}))
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		resp.Body.Close()
+		if tracker.open != 0 {
+			t.Errorf("expected every body to be closed, %d still open", tracker.open)
+		}
+	})
+
+	t.Run("retries exhausted", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+			fmt.Fprint(w, "down")
+		}))
+		defer server.Close()
+
+		tracker := &closeTracker{}
+		client := New(logger, cfg, WithName("test-close-exhausted"), WithHTTPClient(&http.Client{Transport: tracker}))
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+
+		if _, err := client.Do(req); err == nil {
+			t.Fatal("expected an error")
+		}
+		if tracker.open != 0 {
+			t.Errorf("expected every body to be closed, %d still open", tracker.open)
+		}
+	})
+}
+
+func TestHttpClient_LocalizedErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	cfg := &Config{MaxRetries: 1, RetryDelay: time.Millisecond, CBThreshold: 1, CBTimeout: time.Minute, Timeout: time.Second}
+	client := New(&mockLogger{}, cfg, WithName("httputil-localized-test"))
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	_, failed := client.Do(req)
+	_, open := client.Do(req)
+
+	notFound := httptest.NewServer(http.NotFoundHandler())
+	defer notFound.Close()
+	req, _ = http.NewRequest(http.MethodGet, notFound.URL, nil)
+	_, apiErr := DoJSON[map[string]any](context.Background(), New(&mockLogger{}, cfg), req)
+
+	tests := []struct {
+		err      error
+		expected string
+	}{
+		{failed, "HTTP request failed"},
+		{open, "external service unavailable (circuit open)"},
+		{(&httpClient{}).mapError(errors.New("dial tcp: timeout"), nil), "network error or timeout"},
+		{apiErr, "error response from external API"},
+	}
+
+	handler := mw.AppErrorHandler(&mockLogger{})
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Accept-Language", "en")
+		rec := httptest.NewRecorder()
+
+		handler(tt.err, echo.New().NewContext(req, rec))
+
+		var body struct {
+			Message string `json:"message"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		if body.Message != tt.expected {
+			t.Errorf("%v: expected %q, got %q", tt.err, tt.expected, body.Message)
+		}
+	}
+}
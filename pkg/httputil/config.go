@@ -14,6 +14,9 @@ type Config struct {
 	MaxRetries uint `env:"HTTP_MAX_RETRIES" envDefault:"3"`
 	// RetryDelay is the initial delay for exponential backoff.
 	RetryDelay time.Duration `env:"HTTP_RETRY_DELAY" envDefault:"1s"`
+	// MaxRetryAfter is the longest Retry-After a server may ask for and still be retried; longer
+	// waits end the retries and return the error. With zero, such responses are not retried.
+	MaxRetryAfter time.Duration `env:"HTTP_MAX_RETRY_AFTER" envDefault:"30s"`
 	// CBThreshold is the number of consecutive failures before the circuit breaker opens.
 	CBThreshold uint32 `env:"HTTP_CB_THRESHOLD" envDefault:"10"`
 	// CBTimeout is the duration the circuit breaker stays open before transitioning to half-open.
@@ -23,11 +26,12 @@ type Config struct {
 // DefaultConfig returns a default configuration for the HTTP client.
 func DefaultConfig() *Config {
 	return &Config{
-		Timeout:     30 * time.Second,
-		DialTimeout: 5 * time.Second,
-		MaxRetries:  3,
-		RetryDelay:  1 * time.Second,
-		CBThreshold: 10,
-		CBTimeout:   1 * time.Minute,
+		Timeout:       30 * time.Second,
+		DialTimeout:   5 * time.Second,
+		MaxRetries:    3,
+		RetryDelay:    1 * time.Second,
+		MaxRetryAfter: 30 * time.Second,
+		CBThreshold:   10,
+		CBTimeout:     1 * time.Minute,
 	}
 }
@@ -4,12 +4,17 @@
 // resilience (Retries, Circuit Breaking) and observability (Logging, Tracing).
 //
 // Key Features:
-// - Circuit Breaker: Automatically "opens" after a configurable threshold of failures.
-// - Retries: Automatic retries with Exponential Backoff for 5xx and network errors.
-// - Observability: Automatic logging of Method, URL, Status, and Latency.
-// - Tracing: Automatic propagation of X-Request-ID.
-// - Generic Helpers: Type-safe JSON decoding with DoJSON[T] helper.
-// - Error Mapping: Automatic mapping of HTTP status codes to application errors (apperr).
+//   - Circuit Breaker: Automatically "opens" after a configurable threshold of failures; calls
+//     made while it is open fail with apperr.ErrUnavailable, retryable after CBTimeout.
+//   - Retries: Automatic retries with Exponential Backoff for 5xx and network errors. A
+//     Retry-After header (on 5xx or 429 responses) replaces the backoff, up to MaxRetryAfter,
+//     and is kept in the returned error (apperr.RetryAfterOf).
+//   - Observability: Automatic logging of Method, URL, Status, and Latency.
+//   - Tracing: Automatic propagation of X-Request-ID.
+//   - Generic Helpers: Type-safe JSON decoding with DoJSON[T] helper.
+//   - Error Mapping: Automatic mapping of HTTP status codes to application errors (apperr).
+//   - Reload: retry and circuit breaker thresholds can be reloaded at runtime (OnReload)
+//     from the loader set with WithConfigLoader.
 //
 // Example (Simple):
 //
@@ -17,6 +22,11 @@
 //	req, _ := http.NewRequest(http.MethodGet, "https://api.example.com/data", nil)
 //	resp, err := client.Do(req)
 //
+// Example (One client per external API):
+//
+//	paymentsCfg := config.MustLoad[httputil.Config](config.WithPrefix("PAYMENTS_"))
+//	payments := httputil.New(logger, paymentsCfg)
+//
 // Example (Generic JSON):
 //
 //	data, err := httputil.DoJSON[MyType](ctx, client, req)
@@ -0,0 +1,33 @@
+/*
+Package i18n provides the message catalogue used to localize the messages returned to clients.
+
+The catalogue holds one bundle of messages per locale, indexed by key. The kit ships Spanish
+(the language in which every message of the kit is written) and English bundles with the
+messages of each apperr error code (CodeKey), of the most common validator tags (TagKey) and
+of the fixed messages of the kit (MessageKey, keyed by the Spanish text). Messages may contain
+{field}, {tag}, {param} and {count} placeholders. Messages with parameters, such as the size
+limit of binder, are keyed by their Spanish template (e.g. "... supera el límite de
+{max_body_size} bytes"); the error records the key and values with apperr's WithMessageKey, so
+mw.AppErrorHandler can render it in the locale of the request.
+
+Features:
+  - Catalog with per-locale bundles, regional fallback ("en-US" → "en") and a fallback locale.
+  - Default catalogue shared by check and mw; applications add keys or locales with Add.
+  - Accept-Language negotiation with quality values (Negotiate).
+  - Context helpers (SetInContext, FromContext, LocaleOrDefault) to propagate the locale of
+    the request, which mw.WithLocale stores for every request.
+
+Example usage:
+
+	i18n.Default().Add("pt", map[string]string{
+		i18n.CodeKey("NOT_FOUND"): "Recurso não encontrado",
+		i18n.TagKey("required"):   "O campo '{field}' é obrigatório",
+	})
+
+	locale := i18n.Default().Negotiate("pt-BR,pt;q=0.9,en;q=0.8") // "pt"
+	msg := i18n.Default().TranslateTag(locale, "email", "required", "")
+
+	// In a handler, after mw.WithLocale
+	locale = i18n.LocaleOrDefault(c.Request().Context())
+*/
+package i18n
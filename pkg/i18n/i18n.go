@@ -0,0 +1,249 @@
+package i18n
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	// Spanish is the locale in which the kit messages are written.
+	Spanish Locale = "es"
+	// English is the English locale.
+	English Locale = "en"
+	// DefaultLocale is the locale used when the client does not ask for a supported one.
+	DefaultLocale = Spanish
+
+	// SummaryKey is the catalog key of the message of a validation error with several field
+	// violations, which receives their number in the {count} placeholder.
+	SummaryKey = "validation.summary"
+)
+
+type (
+	// Locale identifies a language, e.g. "es" or "en-US".
+	Locale string
+
+	// Catalog holds the messages of every supported locale, indexed by key.
+	// Messages may contain placeholders such as {field} or {param}, replaced by Translate.
+	Catalog struct {
+		// mu guards messages.
+		mu sync.RWMutex
+		// messages maps each locale to its messages.
+		messages map[Locale]map[string]string
+		// fallback is the locale used when a message is missing in the requested one.
+		fallback Locale
+	}
+
+	// localeContextKey is a private type for storing the locale in the context.
+	localeContextKey struct{}
+)
+
+var (
+	// localeKey is the unique key used to store/retrieve the Locale from the context.
+	localeKey = localeContextKey{}
+
+	// defaultCatalog is the catalog with the kit messages, shared by every package.
+	defaultCatalog = NewCatalog(DefaultLocale)
+)
+
+func init() {
+	defaultCatalog.Add(Spanish, spanishMessages)
+	defaultCatalog.Add(English, englishMessages)
+}
+
+// CodeKey returns the catalog key of the message of an error code (e.g. "NOT_FOUND").
+func CodeKey(code string) string {
+	return "code." + code
+}
+
+// TagKey returns the catalog key of the message of a validation tag (e.g. "required").
+func TagKey(tag string) string {
+	return "validation." + tag
+}
+
+// MessageKey returns the catalog key of the translation of a fixed message written in
+// DefaultLocale (e.g. "Datos de entrada no válidos"), so errors with that message can be
+// shown in other locales.
+func MessageKey(msg string) string {
+	return "message." + msg
+}
+
+// NewCatalog creates an empty catalog that falls back to the given locale.
+func NewCatalog(fallback Locale) *Catalog {
+	return &Catalog{
+		messages: make(map[Locale]map[string]string),
+		fallback: fallback.normalize(),
+	}
+}
+
+// Default returns the catalog with the Spanish and English messages of the kit. Applications
+// can add their own keys or locales to it with Add.
+func Default() *Catalog {
+	return defaultCatalog
+}
+
+// Add adds messages to a locale, replacing the existing ones with the same key.
+func (c *Catalog) Add(locale Locale, messages map[string]string) {
+	locale = locale.normalize()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	bundle, ok := c.messages[locale]
+	if !ok {
+		bundle = make(map[string]string, len(messages))
+		c.messages[locale] = bundle
+	}
+	for k, v := range messages {
+		bundle[k] = v
+	}
+}
+
+// Fallback returns the locale used when a message is missing in the requested one.
+func (c *Catalog) Fallback() Locale {
+	return c.fallback
+}
+
+// Locales returns the locales with messages, sorted.
+func (c *Catalog) Locales() []Locale {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	locales := make([]Locale, 0, len(c.messages))
+	for l := range c.messages {
+		locales = append(locales, l)
+	}
+	slices.Sort(locales)
+	return locales
+}
+
+// Lookup returns the message of a key in a locale. A regional locale ("en-US") without the
+// message falls back to its language ("en"); other locales are not consulted.
+func (c *Catalog) Lookup(locale Locale, key string) (string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	locale = locale.normalize()
+	if msg, ok := c.messages[locale][key]; ok {
+		return msg, true
+	}
+	msg, ok := c.messages[locale.Base()][key]
+	return msg, ok
+}
+
+// Translate returns the message of a key in a locale, or in the fallback locale if the
+// locale does not have it, with every {name} placeholder replaced by params[name]. If no
+// locale has the message, it returns the key.
+func (c *Catalog) Translate(locale Locale, key string, params map[string]string) string {
+	msg, ok := c.Lookup(locale, key)
+	if !ok {
+		if msg, ok = c.Lookup(c.fallback, key); !ok {
+			return key
+		}
+	}
+
+	if len(params) == 0 {
+		return msg
+	}
+	pairs := make([]string, 0, len(params)*2)
+	for k, v := range params {
+		pairs = append(pairs, "{"+k+"}", v)
+	}
+	return strings.NewReplacer(pairs...).Replace(msg)
+}
+
+// TranslateTag returns the message of a failed validation rule, using the "default" rule
+// message for tags without one.
+func (c *Catalog) TranslateTag(locale Locale, field, tag, param string) string {
+	key := TagKey(tag)
+	if _, ok := c.Lookup(locale, key); !ok {
+		if _, ok := c.Lookup(c.fallback, key); !ok {
+			key = TagKey("default")
+		}
+	}
+	return c.Translate(locale, key, map[string]string{"field": field, "tag": tag, "param": param})
+}
+
+// Negotiate returns the locale of the catalog that best matches an Accept-Language header,
+// e.g. "en-US,en;q=0.9,es;q=0.8". Languages are tried by decreasing quality, first as sent
+// and then by their base language. If none matches, it returns the fallback locale.
+func (c *Catalog) Negotiate(acceptLanguage string) Locale {
+	type candidate struct {
+		locale Locale
+		q      float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			var err error
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+		if q > 0 {
+			candidates = append(candidates, candidate{Locale(tag).normalize(), q})
+		}
+	}
+	slices.SortStableFunc(candidates, func(a, b candidate) int {
+		switch {
+		case a.q > b.q:
+			return -1
+		case a.q < b.q:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, cand := range candidates {
+		if _, ok := c.messages[cand.locale]; ok {
+			return cand.locale
+		}
+		if _, ok := c.messages[cand.locale.Base()]; ok {
+			return cand.locale.Base()
+		}
+	}
+	return c.fallback
+}
+
+// Base returns the language of the locale without its region, e.g. "en" for "en-US".
+func (l Locale) Base() Locale {
+	base, _, _ := strings.Cut(string(l), "-")
+	return Locale(base)
+}
+
+// normalize returns the locale in lower case with "-" as separator, e.g. "en-us" for "en_US".
+func (l Locale) normalize() Locale {
+	return Locale(strings.ToLower(strings.ReplaceAll(string(l), "_", "-")))
+}
+
+// FromContext retrieves the Locale from the context.
+func FromContext(ctx context.Context) (Locale, bool) {
+	l, ok := ctx.Value(localeKey).(Locale)
+	return l, ok
+}
+
+// SetInContext injects the Locale into the context.
+func SetInContext(ctx context.Context, l Locale) context.Context {
+	return context.WithValue(ctx, localeKey, l)
+}
+
+// LocaleOrDefault returns the Locale stored in the context, or DefaultLocale if there is none.
+func LocaleOrDefault(ctx context.Context) Locale {
+	if l, ok := FromContext(ctx); ok {
+		return l
+	}
+	return DefaultLocale
+}
@@ -0,0 +1,106 @@
+package i18n
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCatalog_Translate(t *testing.T) {
+	c := NewCatalog(Spanish)
+	c.Add(Spanish, map[string]string{"greeting": "Hola {name}", "only_es": "Solo español"})
+	c.Add("en", map[string]string{"greeting": "Hello {name}"})
+	c.Add("en-GB", map[string]string{"colour": "Colour"})
+
+	tests := []struct {
+		name     string
+		locale   Locale
+		key      string
+		expected string
+	}{
+		{"exact locale", English, "greeting", "Hello Ana"},
+		{"regional falls back to language", "en-US", "greeting", "Hello Ana"},
+		{"regional message", "en_GB", "colour", "Colour"},
+		{"missing falls back to fallback locale", English, "only_es", "Solo español"},
+		{"unknown locale", "fr", "greeting", "Hola Ana"},
+		{"unknown key", English, "missing", "missing"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := c.Translate(tt.locale, tt.key, map[string]string{"name": "Ana"}); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+
+	if locales := c.Locales(); len(locales) != 3 || locales[0] != "en" || locales[2] != "es" {
+		t.Errorf("unexpected locales: %v", locales)
+	}
+}
+
+func TestCatalog_TranslateTag(t *testing.T) {
+	c := Default()
+
+	if got := c.TranslateTag(English, "email", "required", ""); got != "Field 'email' is required" {
+		t.Errorf("unexpected message: %s", got)
+	}
+	if got := c.TranslateTag(Spanish, "age", "min", "18"); got != "El campo 'age' es demasiado corto (mínimo 18)" {
+		t.Errorf("unexpected message: %s", got)
+	}
+	if got := c.TranslateTag(English, "code", "custom_rule", ""); got != "Field 'code' failed on the 'custom_rule' rule" {
+		t.Errorf("unexpected default message: %s", got)
+	}
+}
+
+func TestCatalog_Bundles(t *testing.T) {
+	for key := range spanishMessages {
+		if _, ok := englishMessages[key]; !ok {
+			t.Errorf("key %s is missing in the English bundle", key)
+		}
+	}
+	for key := range englishMessages {
+		if _, ok := spanishMessages[key]; !ok {
+			t.Errorf("key %s is missing in the Spanish bundle", key)
+		}
+	}
+}
+
+func TestCatalog_Negotiate(t *testing.T) {
+	c := Default()
+
+	tests := []struct {
+		header   string
+		expected Locale
+	}{
+		{"", Spanish},
+		{"*", Spanish},
+		{"en", English},
+		{"en-US,en;q=0.9", English},
+		{"fr-FR, en;q=0.5, es;q=0.8", Spanish},
+		{"fr, de", Spanish},
+		{"es;q=0, en", English},
+		{"EN-us", English},
+	}
+
+	for _, tt := range tests {
+		if got := c.Negotiate(tt.header); got != tt.expected {
+			t.Errorf("Accept-Language %q: expected %s, got %s", tt.header, tt.expected, got)
+		}
+	}
+}
+
+func TestContext(t *testing.T) {
+	ctx := context.Background()
+
+	if _, ok := FromContext(ctx); ok {
+		t.Error("expected no locale in empty context")
+	}
+	if got := LocaleOrDefault(ctx); got != DefaultLocale {
+		t.Errorf("expected %s, got %s", DefaultLocale, got)
+	}
+
+	ctx = SetInContext(ctx, English)
+	if got, ok := FromContext(ctx); !ok || got != English {
+		t.Errorf("expected %s in context, got %s", English, got)
+	}
+}
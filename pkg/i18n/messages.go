@@ -0,0 +1,187 @@
+package i18n
+
+var (
+	// spanishMessages is the Spanish bundle of the kit.
+	spanishMessages = map[string]string{
+		CodeKey("INVALID_ARGUMENT"):    "Los datos de entrada no son válidos",
+		CodeKey("UNAUTHENTICATED"):     "Se requiere autenticación",
+		CodeKey("PERMISSION_DENIED"):   "No tiene permisos suficientes",
+		CodeKey("NOT_FOUND"):           "Recurso no encontrado",
+		CodeKey("ALREADY_EXISTS"):      "El recurso ya existe",
+		CodeKey("INTERNAL_ERROR"):      "Ocurrió un error inesperado",
+		CodeKey("NOT_IMPLEMENTED"):     "Funcionalidad no implementada",
+		CodeKey("SERVICE_UNAVAILABLE"): "Servicio no disponible temporalmente",
+		CodeKey("TIMEOUT"):             "Tiempo de espera agotado",
+		CodeKey("FAILED_PRECONDITION"): "La operación no es posible en el estado actual",
+		CodeKey("RESOURCE_EXHAUSTED"):  "Se superó el límite de peticiones",
+		CodeKey("ABORTED"):             "La operación fue abortada por un conflicto",
+		CodeKey("OUT_OF_RANGE"):        "Valor fuera de rango",
+		CodeKey("CANCELLED"):           "La petición fue cancelada",
+		CodeKey("DATA_LOSS"):           "Pérdida de datos irrecuperable",
+		CodeKey("UNKNOWN"):             "Error desconocido",
+
+		TagKey("default"):     "Error en el campo '{field}': regla '{tag}' no cumplida",
+		TagKey("required"):    "El campo '{field}' es obligatorio",
+		TagKey("email"):       "El campo '{field}' debe ser un correo electrónico válido",
+		TagKey("min"):         "El campo '{field}' es demasiado corto (mínimo {param})",
+		TagKey("max"):         "El campo '{field}' es demasiado largo (máximo {param})",
+		TagKey("len"):         "El campo '{field}' debe tener una longitud de {param}",
+		TagKey("eq"):          "El campo '{field}' debe ser igual a {param}",
+		TagKey("ne"):          "El campo '{field}' no puede ser {param}",
+		TagKey("gt"):          "El campo '{field}' debe ser mayor que {param}",
+		TagKey("gte"):         "El campo '{field}' debe ser mayor o igual que {param}",
+		TagKey("lt"):          "El campo '{field}' debe ser menor que {param}",
+		TagKey("lte"):         "El campo '{field}' debe ser menor o igual que {param}",
+		TagKey("oneof"):       "El campo '{field}' debe ser uno de: {param}",
+		TagKey("eqfield"):     "El campo '{field}' debe ser igual a '{param}'",
+		TagKey("nefield"):     "El campo '{field}' debe ser distinto de '{param}'",
+		TagKey("url"):         "El campo '{field}' debe ser una URL válida",
+		TagKey("uri"):         "El campo '{field}' debe ser una URI válida",
+		TagKey("uuid"):        "El campo '{field}' debe ser un UUID válido",
+		TagKey("numeric"):     "El campo '{field}' debe ser numérico",
+		TagKey("number"):      "El campo '{field}' debe ser un número",
+		TagKey("alpha"):       "El campo '{field}' solo puede contener letras",
+		TagKey("alphanum"):    "El campo '{field}' solo puede contener letras y números",
+		TagKey("boolean"):     "El campo '{field}' debe ser un booleano",
+		TagKey("datetime"):    "El campo '{field}' debe ser una fecha con el formato {param}",
+		TagKey("e164"):        "El campo '{field}' debe ser un teléfono en formato E.164",
+		TagKey("ip"):          "El campo '{field}' debe ser una dirección IP válida",
+		TagKey("json"):        "El campo '{field}' debe ser un JSON válido",
+		TagKey("contains"):    "El campo '{field}' debe contener '{param}'",
+		TagKey("excludes"):    "El campo '{field}' no puede contener '{param}'",
+		TagKey("startswith"):  "El campo '{field}' debe empezar por '{param}'",
+		TagKey("endswith"):    "El campo '{field}' debe terminar en '{param}'",
+		TagKey("unique"):      "El campo '{field}' no puede contener valores repetidos",
+		TagKey("required_if"): "El campo '{field}' es obligatorio cuando {param}",
+		TagKey("dive"):        "El campo '{field}' contiene elementos no válidos",
+		TagKey("unknown"):     "El campo '{field}' no está permitido",
+		TagKey("type"):        "El campo '{field}' debe ser de tipo {param}",
+
+		SummaryKey: "Los datos de entrada contienen {count} campos no válidos",
+
+		MessageKey("Datos de entrada no válidos"):                                        "Datos de entrada no válidos",
+		MessageKey("Parámetros de ruta no válidos"):                                      "Parámetros de ruta no válidos",
+		MessageKey("Parámetros de consulta no válidos"):                                  "Parámetros de consulta no válidos",
+		MessageKey("Encabezados no válidos"):                                             "Encabezados no válidos",
+		MessageKey("Cuerpo de la petición no válido"):                                    "Cuerpo de la petición no válido",
+		MessageKey("El cuerpo de la petición no es un JSON válido"):                      "El cuerpo de la petición no es un JSON válido",
+		MessageKey("El cuerpo de la petición contiene datos después del JSON"):           "El cuerpo de la petición contiene datos después del JSON",
+		MessageKey("error inesperado"):                                                   "error inesperado",
+		MessageKey("Ocurrió un error inesperado"):                                        "Ocurrió un error inesperado",
+		MessageKey("Error interno de validación"):                                        "Error interno de validación",
+		MessageKey("El cuerpo de la petición supera el límite de {max_body_size} bytes"): "El cuerpo de la petición supera el límite de {max_body_size} bytes",
+		MessageKey(`el encabezado "{header}" es requerido para identificar el tenant`):   `el encabezado "{header}" es requerido para identificar el tenant`,
+		MessageKey("Nivel de log no válido"):                                             "Nivel de log no válido",
+		MessageKey("Duración no válida"):                                                 "Duración no válida",
+		MessageKey("La duración supera el máximo permitido"):                             "La duración supera el máximo permitido",
+		MessageKey("Logger no encontrado"):                                               "Logger no encontrado",
+		MessageKey("registro no encontrado"):                                             "registro no encontrado",
+		MessageKey("el registro ya existe"):                                              "el registro ya existe",
+		MessageKey("violación de integridad de datos"):                                   "violación de integridad de datos",
+		MessageKey("conflicto de concurrencia en la base de datos"):                      "conflicto de concurrencia en la base de datos",
+		MessageKey("base de datos no disponible"):                                        "base de datos no disponible",
+		MessageKey("error inesperado en la base de datos"):                               "error inesperado en la base de datos",
+		MessageKey("error inesperado en la base de datos MySQL"):                         "error inesperado en la base de datos MySQL",
+		MessageKey("pool de base de datos no inicializado"):                              "pool de base de datos no inicializado",
+		MessageKey("error al iniciar transacción"):                                       "error al iniciar transacción",
+		MessageKey("error al confirmar transacción"):                                     "error al confirmar transacción",
+		MessageKey("servicio externo no disponible (circuito abierto)"):                  "servicio externo no disponible (circuito abierto)",
+		MessageKey("error en petición HTTP"):                                             "error en petición HTTP",
+		MessageKey("error de red o timeout"):                                             "error de red o timeout",
+		MessageKey("error en respuesta de API externa"):                                  "error en respuesta de API externa",
+		MessageKey("error al leer el cuerpo de la respuesta"):                            "error al leer el cuerpo de la respuesta",
+		MessageKey("error al decodificar respuesta JSON"):                                "error al decodificar respuesta JSON",
+	}
+
+	// englishMessages is the English bundle of the kit.
+	englishMessages = map[string]string{
+		CodeKey("INVALID_ARGUMENT"):    "The request contains invalid data",
+		CodeKey("UNAUTHENTICATED"):     "Authentication required",
+		CodeKey("PERMISSION_DENIED"):   "Insufficient permissions",
+		CodeKey("NOT_FOUND"):           "Resource not found",
+		CodeKey("ALREADY_EXISTS"):      "Resource already exists",
+		CodeKey("INTERNAL_ERROR"):      "An unexpected error occurred",
+		CodeKey("NOT_IMPLEMENTED"):     "Feature not implemented",
+		CodeKey("SERVICE_UNAVAILABLE"): "Service temporarily unavailable",
+		CodeKey("TIMEOUT"):             "Request timeout",
+		CodeKey("FAILED_PRECONDITION"): "The operation is not allowed in the current state",
+		CodeKey("RESOURCE_EXHAUSTED"):  "Request limit exceeded",
+		CodeKey("ABORTED"):             "The operation was aborted due to a conflict",
+		CodeKey("OUT_OF_RANGE"):        "Value out of range",
+		CodeKey("CANCELLED"):           "The request was cancelled",
+		CodeKey("DATA_LOSS"):           "Unrecoverable data loss",
+		CodeKey("UNKNOWN"):             "Unknown error",
+
+		TagKey("default"):     "Field '{field}' failed on the '{tag}' rule",
+		TagKey("required"):    "Field '{field}' is required",
+		TagKey("email"):       "Field '{field}' must be a valid email address",
+		TagKey("min"):         "Field '{field}' is too short (minimum {param})",
+		TagKey("max"):         "Field '{field}' is too long (maximum {param})",
+		TagKey("len"):         "Field '{field}' must have a length of {param}",
+		TagKey("eq"):          "Field '{field}' must be equal to {param}",
+		TagKey("ne"):          "Field '{field}' cannot be {param}",
+		TagKey("gt"):          "Field '{field}' must be greater than {param}",
+		TagKey("gte"):         "Field '{field}' must be greater than or equal to {param}",
+		TagKey("lt"):          "Field '{field}' must be less than {param}",
+		TagKey("lte"):         "Field '{field}' must be less than or equal to {param}",
+		TagKey("oneof"):       "Field '{field}' must be one of: {param}",
+		TagKey("eqfield"):     "Field '{field}' must be equal to '{param}'",
+		TagKey("nefield"):     "Field '{field}' must be different from '{param}'",
+		TagKey("url"):         "Field '{field}' must be a valid URL",
+		TagKey("uri"):         "Field '{field}' must be a valid URI",
+		TagKey("uuid"):        "Field '{field}' must be a valid UUID",
+		TagKey("numeric"):     "Field '{field}' must be numeric",
+		TagKey("number"):      "Field '{field}' must be a number",
+		TagKey("alpha"):       "Field '{field}' can only contain letters",
+		TagKey("alphanum"):    "Field '{field}' can only contain letters and numbers",
+		TagKey("boolean"):     "Field '{field}' must be a boolean",
+		TagKey("datetime"):    "Field '{field}' must be a date in the {param} format",
+		TagKey("e164"):        "Field '{field}' must be a phone number in E.164 format",
+		TagKey("ip"):          "Field '{field}' must be a valid IP address",
+		TagKey("json"):        "Field '{field}' must be valid JSON",
+		TagKey("contains"):    "Field '{field}' must contain '{param}'",
+		TagKey("excludes"):    "Field '{field}' cannot contain '{param}'",
+		TagKey("startswith"):  "Field '{field}' must start with '{param}'",
+		TagKey("endswith"):    "Field '{field}' must end with '{param}'",
+		TagKey("unique"):      "Field '{field}' cannot contain repeated values",
+		TagKey("required_if"): "Field '{field}' is required when {param}",
+		TagKey("dive"):        "Field '{field}' contains invalid items",
+		TagKey("unknown"):     "Field '{field}' is not allowed",
+		TagKey("type"):        "Field '{field}' must be of type {param}",
+
+		SummaryKey: "The request contains {count} invalid fields",
+
+		MessageKey("Datos de entrada no válidos"):                                        "Invalid input data",
+		MessageKey("Parámetros de ruta no válidos"):                                      "Invalid path parameters",
+		MessageKey("Parámetros de consulta no válidos"):                                  "Invalid query parameters",
+		MessageKey("Encabezados no válidos"):                                             "Invalid headers",
+		MessageKey("Cuerpo de la petición no válido"):                                    "Invalid request body",
+		MessageKey("El cuerpo de la petición no es un JSON válido"):                      "The request body is not valid JSON",
+		MessageKey("El cuerpo de la petición contiene datos después del JSON"):           "The request body contains data after the JSON",
+		MessageKey("error inesperado"):                                                   "Unexpected error",
+		MessageKey("Ocurrió un error inesperado"):                                        "An unexpected error occurred",
+		MessageKey("Error interno de validación"):                                        "Internal validation error",
+		MessageKey("El cuerpo de la petición supera el límite de {max_body_size} bytes"): "The request body exceeds the limit of {max_body_size} bytes",
+		MessageKey(`el encabezado "{header}" es requerido para identificar el tenant`):   `the "{header}" header is required to identify the tenant`,
+		MessageKey("Nivel de log no válido"):                                             "Invalid log level",
+		MessageKey("Duración no válida"):                                                 "Invalid duration",
+		MessageKey("La duración supera el máximo permitido"):                             "The duration exceeds the maximum allowed",
+		MessageKey("Logger no encontrado"):                                               "Logger not found",
+		MessageKey("registro no encontrado"):                                             "record not found",
+		MessageKey("el registro ya existe"):                                              "the record already exists",
+		MessageKey("violación de integridad de datos"):                                   "data integrity violation",
+		MessageKey("conflicto de concurrencia en la base de datos"):                      "database concurrency conflict",
+		MessageKey("base de datos no disponible"):                                        "database unavailable",
+		MessageKey("error inesperado en la base de datos"):                               "unexpected database error",
+		MessageKey("error inesperado en la base de datos MySQL"):                         "unexpected MySQL database error",
+		MessageKey("pool de base de datos no inicializado"):                              "database pool not initialized",
+		MessageKey("error al iniciar transacción"):                                       "failed to begin the transaction",
+		MessageKey("error al confirmar transacción"):                                     "failed to commit the transaction",
+		MessageKey("servicio externo no disponible (circuito abierto)"):                  "external service unavailable (circuit open)",
+		MessageKey("error en petición HTTP"):                                             "HTTP request failed",
+		MessageKey("error de red o timeout"):                                             "network error or timeout",
+		MessageKey("error en respuesta de API externa"):                                  "error response from external API",
+		MessageKey("error al leer el cuerpo de la respuesta"):                            "failed to read the response body",
+		MessageKey("error al decodificar respuesta JSON"):                                "failed to decode the JSON response",
+	}
+)
@@ -7,22 +7,84 @@ components, while also ensuring a graceful shutdown by handling OS interruption
 Components registered with the Launcher must implement the Component interface, which
 defines methods for initialization (OnInit), startup (OnStart), and shutdown (OnStop).
 
+Components may optionally implement Named (a unique name) and Dependent (the names of the
+components they depend on). The launcher builds a dependency graph from them, rejecting
+unknown dependencies, duplicate names and cycles, and groups the components in layers:
+components in the same layer do not depend on each other and are handled in parallel.
+
 The launcher follows a strict lifecycle:
-1. OnInit: Synchronously initializes all components in the order they were appended.
+1. OnInit: Initializes all components, layer by layer in dependency order.
 2. BeforeStart: Executes assembly hooks (useful for manual DI or late binding).
-3. OnStart: Synchronously starts all components.
-4. Signal Handling: Waits for SIGINT (Interrupt) or SIGTERM.
-5. Graceful Shutdown: Synchronously stops all components in reverse order.
+3. OnStart: Starts all components, layer by layer in dependency order.
+4. AfterStart: Executes hooks once the application is running (e.g. service discovery).
+5. Signal Handling: Waits for SIGINT (Interrupt) or SIGTERM.
+6. BeforeStop: Executes hooks before any component is stopped (e.g. flipping readiness).
+7. Graceful Shutdown: Stops all components in reverse dependency order.
+8. AfterStop: Executes hooks once every component has stopped (e.g. flushing telemetry).
+
+The launcher exposes its progress through State (IDLE, STARTING, RUNNING, STOPPING,
+STOPPED), plus Started and Ready, which makes it a health.Probe: readiness turns false before
+the BeforeStop hooks run, and WithShutdownDelay keeps the components running for a while so
+load balancers stop sending traffic before the server stops accepting connections.
+
+Components implementing Supervised expose a Done channel that reports when their background
+work dies after OnStart. The launcher watches it and applies the RestartPolicy set with
+WithRestartPolicy or WithDefaultRestartPolicy: RestartNever leaves the component stopped,
+RestartOnFailure calls OnStart again with exponential backoff up to MaxRestarts consecutive
+times (a component that keeps running for ResetAfter starts counting again), and a Critical
+component that is not restarted shuts down the whole application (RunContext then
+returns ErrComponentFailed).
+
+Run also catches SIGHUP and calls Reload, which can be triggered programmatically as well.
+Reload calls OnReload on every component implementing Reloadable, in dependency order, and
+then on the reloadables registered with AppendReloadable (e.g. logz.NewLevelReloader). The
+kit components re-run the configuration loader set with their WithConfigLoader option and
+apply the settings that can change without a restart.
+
+Observers registered with WithObserver receive an Event (component, phase, duration and
+error) for every component call, hook list and phase, which allows logging or measuring the
+boot and shutdown time of each component. NewLogObserver provides a logging implementation.
+
+Run handles OS signals and terminates the process when startup fails. RunContext runs the same
+lifecycle but shuts down when its context is cancelled and returns errors instead of exiting,
+which makes it possible to embed the launcher or to test a full service lifecycle. Both flush
+the entries buffered by the asynchronous writers of logz (logz.Flush) before returning. Every
+component call is bounded by a per-component timeout (15 seconds by default, configurable with
+WithComponentTimeout and WithComponentTimeoutFor), and each phase can be bounded as a whole
+with WithInitTimeout, WithStartTimeout and WithStopTimeout. Components that fail or time out
+while stopping are listed in a *ShutdownError.
 
 Example usage:
 
-	appLauncher := launcher.New(logger)
-	appLauncher.Append(dbComponent, serverComponent)
+	db := pgutil.New(logger, pgCfg) // Named "postgres"
+	srv := server.NewEchoServer(logger, srvCfg, server.WithDependencies("postgres"))
+
+	appLauncher := launcher.New(logger,
+		launcher.WithObserver(launcher.NewLogObserver(logger)),
+		launcher.WithRestartPolicy("http-server", launcher.RestartPolicy{
+			Mode:        launcher.RestartOnFailure,
+			MaxRestarts: 3,
+			Critical:    true,
+		}),
+	)
+	appLauncher.Append(srv, db) // db is started before srv regardless of Append order
 	appLauncher.BeforeStart(func() error {
 		// Manual DI here
 		return nil
 	})
+	appLauncher.BeforeStop(func() error {
+		return registry.Deregister(serviceID)
+	})
 
 	appLauncher.Run() // Blocks until signal received
+
+	// In tests or embedded scenarios
+	appLauncher = launcher.New(logger, launcher.WithStopTimeout(30*time.Second))
+	if err := appLauncher.RunContext(ctx); err != nil {
+		var shutdownErr *launcher.ShutdownError
+		if errors.As(err, &shutdownErr) {
+			// shutdownErr.Errors lists each component that failed or timed out
+		}
+	}
 */
 package launcher
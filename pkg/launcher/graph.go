@@ -0,0 +1,121 @@
+package launcher
+
+import (
+	"fmt"
+	"strings"
+)
+
+type (
+	// node is a component placed in the dependency graph.
+	node struct {
+		// name is the component name, either declared through Named or generated.
+		name string
+		// component is the registered component.
+		component Component
+		// deps are the names of the components this one depends on.
+		deps []string
+		// index is the position of the component in Append order.
+		index int
+	}
+
+	// visitState tracks the depth-first search used for cycle detection.
+	visitState int
+)
+
+const (
+	// unvisited marks a node not yet reached by the search.
+	unvisited visitState = iota
+	// visiting marks a node on the current search path.
+	visiting
+	// visited marks a node whose dependencies are fully resolved.
+	visited
+)
+
+// buildLayers arranges the components in dependency layers. Every component in a layer only
+// depends on components in previous layers, so the components of a layer can be handled in
+// parallel. Within a layer, Append order is preserved.
+func buildLayers(components []Component) ([][]*node, error) {
+	nodes := make([]*node, 0, len(components))
+	byName := make(map[string]*node, len(components))
+
+	for i, c := range components {
+		n := &node{
+			name:      componentName(c, i),
+			component: c,
+			index:     i,
+		}
+		if d, ok := c.(Dependent); ok {
+			n.deps = d.DependsOn()
+		}
+
+		if _, exists := byName[n.name]; exists {
+			return nil, fmt.Errorf("launcher: nombre de componente duplicado %q", n.name)
+		}
+		byName[n.name] = n
+		nodes = append(nodes, n)
+	}
+
+	for _, n := range nodes {
+		for _, dep := range n.deps {
+			if _, ok := byName[dep]; !ok {
+				return nil, fmt.Errorf("launcher: el componente %q depende de %q, que no está registrado", n.name, dep)
+			}
+		}
+	}
+
+	levels := make(map[*node]int, len(nodes))
+	states := make(map[*node]visitState, len(nodes))
+	maxLevel := 0
+
+	var visit func(n *node, path []string) (int, error)
+	visit = func(n *node, path []string) (int, error) {
+		switch states[n] {
+		case visited:
+			return levels[n], nil
+		case visiting:
+			return 0, fmt.Errorf("launcher: dependencia circular detectada: %s", strings.Join(append(path, n.name), " -> "))
+		}
+
+		states[n] = visiting
+		level := 0
+		for _, dep := range n.deps {
+			depLevel, err := visit(byName[dep], append(path, n.name))
+			if err != nil {
+				return 0, err
+			}
+			level = max(level, depLevel+1)
+		}
+		states[n] = visited
+		levels[n] = level
+
+		return level, nil
+	}
+
+	for _, n := range nodes {
+		level, err := visit(n, nil)
+		if err != nil {
+			return nil, err
+		}
+		maxLevel = max(maxLevel, level)
+	}
+
+	if len(nodes) == 0 {
+		return nil, nil
+	}
+
+	layers := make([][]*node, maxLevel+1)
+	for _, n := range nodes {
+		layers[levels[n]] = append(layers[levels[n]], n)
+	}
+
+	return layers, nil
+}
+
+// componentName returns the declared name of a component, or a generated one based on its
+// type and registration index when it does not implement Named.
+func componentName(c Component, index int) string {
+	if n, ok := c.(Named); ok && n.Name() != "" {
+		return n.Name()
+	}
+	return fmt.Sprintf("%T#%d", c, index)
+}
@@ -1,8 +1,14 @@
 package launcher
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"os"
 	"os/signal"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -23,15 +29,72 @@ type (
 		OnStop() error
 	}
 
+	// Named is implemented by components that expose a stable name. Names are used to
+	// declare dependencies and to identify components in logs.
+	Named interface {
+		// Name returns the unique name of the component.
+		Name() string
+	}
+
+	// Dependent is implemented by components that must be initialized and started after
+	// other components, and stopped before them.
+	Dependent interface {
+		// DependsOn returns the names of the components this component depends on.
+		DependsOn() []string
+	}
+
 	// Launcher defines the interface for managing the application lifecycle.
 	Launcher interface {
 		// Append adds one or more components to the launcher.
 		Append(components ...Component)
 		// BeforeStart registers hooks to be executed before starting the components.
 		BeforeStart(hooks ...Hook)
+		// AfterStart registers hooks to be executed once every component has started.
+		AfterStart(hooks ...Hook)
+		// BeforeStop registers hooks to be executed when the shutdown begins, before any
+		// component is stopped.
+		BeforeStop(hooks ...Hook)
+		// AfterStop registers hooks to be executed once every component has stopped.
+		AfterStop(hooks ...Hook)
 		// Run starts the initialization, assembly, and component startup sequence.
-		// It also handles OS signals for graceful shutdown.
+		// It also handles OS signals for graceful shutdown and exits the process on failure.
 		Run()
+		// RunContext runs the same sequence as Run, but shuts down when ctx is cancelled and
+		// returns errors instead of exiting the process.
+		RunContext(ctx context.Context) error
+		// State returns the current lifecycle state.
+		State() State
+		// Started reports whether the startup sequence completed.
+		Started() bool
+		// Ready reports whether the application is running and not shutting down.
+		Ready() bool
+		// AppendReloadable registers reloadables that are not components.
+		AppendReloadable(reloadables ...Reloadable)
+		// Reload reloads the configuration of the reloadable components while running.
+		Reload(ctx context.Context) error
+	}
+
+	// Option configures a Launcher created with New.
+	Option func(*launcher)
+
+	// Phase identifies a step of the component lifecycle.
+	Phase string
+
+	// ComponentError reports the failure of a single component or hook list during a
+	// lifecycle phase.
+	ComponentError struct {
+		// Component is the name of the component that failed, or empty for hooks.
+		Component string
+		// Phase is the lifecycle phase in which the component failed.
+		Phase Phase
+		// Err is the error returned by the component, or ErrTimeout if it did not finish in time.
+		Err error
+	}
+
+	// ShutdownError aggregates the components and hooks that failed or timed out while stopping.
+	ShutdownError struct {
+		// Errors holds one entry per failure, in the order they happened.
+		Errors []*ComponentError
 	}
 
 	// launcher is the concrete implementation of the Launcher interface.
@@ -42,15 +105,142 @@ type (
 		components []Component
 		// onBeforeStart is the list of hooks to execute before startup.
 		onBeforeStart []Hook
+		// onAfterStart is the list of hooks to execute once the components have started.
+		onAfterStart []Hook
+		// onBeforeStop is the list of hooks to execute before the components are stopped.
+		onBeforeStop []Hook
+		// onAfterStop is the list of hooks to execute once the components have stopped.
+		onAfterStop []Hook
+		// observers receive an event for every lifecycle step.
+		observers []Observer
+		// shutdownDelay is the time waited after the BeforeStop hooks before stopping the
+		// components, so load balancers can notice the application is no longer ready.
+		shutdownDelay time.Duration
+		// state holds the current State.
+		state atomic.Int32
+		// reachedRunning reports whether the launcher ever reached StateRunning.
+		reachedRunning atomic.Bool
+		// restartPolicies maps component names to their supervision policy.
+		restartPolicies map[string]RestartPolicy
+		// defaultRestartPolicy applies to supervised components without a specific policy.
+		defaultRestartPolicy RestartPolicy
+		// reloadables are reloaded after the components on every Reload.
+		reloadables []Reloadable
+		// layers is the dependency graph of the current run, used by Reload.
+		layers [][]*node
+		// reloadMu serializes reloads.
+		reloadMu sync.Mutex
+		// phaseTimeouts bounds the total duration of each phase. Zero means no limit.
+		phaseTimeouts map[Phase]time.Duration
+		// componentTimeout bounds each lifecycle call of a single component. Zero means no limit.
+		componentTimeout time.Duration
+		// componentTimeouts overrides componentTimeout for specific components, by name.
+		componentTimeouts map[string]time.Duration
 	}
 )
 
+const (
+	// PhaseInit is the OnInit phase.
+	PhaseInit Phase = "OnInit"
+	// PhaseStart is the OnStart phase.
+	PhaseStart Phase = "OnStart"
+	// PhaseStop is the OnStop phase.
+	PhaseStop Phase = "OnStop"
+	// PhaseBeforeStart is the execution of the BeforeStart hooks.
+	PhaseBeforeStart Phase = "BeforeStart"
+	// PhaseAfterStart is the execution of the AfterStart hooks.
+	PhaseAfterStart Phase = "AfterStart"
+	// PhaseBeforeStop is the execution of the BeforeStop hooks.
+	PhaseBeforeStop Phase = "BeforeStop"
+	// PhaseAfterStop is the execution of the AfterStop hooks.
+	PhaseAfterStop Phase = "AfterStop"
+	// PhaseRun is the running period of the application, when supervised components may die.
+	PhaseRun Phase = "Run"
+	// PhaseRestart is the OnStart call used to restart a supervised component.
+	PhaseRestart Phase = "Restart"
+	// PhaseReload is the OnReload call of a reloadable component.
+	PhaseReload Phase = "OnReload"
+
+	// defaultComponentTimeout is the time a single component is given for each lifecycle call.
+	defaultComponentTimeout = 15 * time.Second
+)
+
+var (
+	// ErrTimeout is reported for components that did not complete a lifecycle call in time.
+	ErrTimeout = errors.New("launcher: tiempo de espera agotado")
+	// errStartup marks errors that happened before the application was ready.
+	errStartup = errors.New("launcher: fallo en el arranque")
+)
+
+// WithInitTimeout bounds the total duration of the OnInit phase.
+func WithInitTimeout(d time.Duration) Option {
+	return func(l *launcher) {
+		l.phaseTimeouts[PhaseInit] = d
+	}
+}
+
+// WithStartTimeout bounds the total duration of the OnStart phase.
+func WithStartTimeout(d time.Duration) Option {
+	return func(l *launcher) {
+		l.phaseTimeouts[PhaseStart] = d
+	}
+}
+
+// WithStopTimeout bounds the total duration of the graceful shutdown.
+func WithStopTimeout(d time.Duration) Option {
+	return func(l *launcher) {
+		l.phaseTimeouts[PhaseStop] = d
+	}
+}
+
+// WithComponentTimeout sets the time each component is given for every OnInit, OnStart and
+// OnStop call (15 seconds by default). Zero disables the limit.
+func WithComponentTimeout(d time.Duration) Option {
+	return func(l *launcher) {
+		l.componentTimeout = d
+	}
+}
+
+// WithComponentTimeoutFor overrides the component timeout for the component with the given name.
+func WithComponentTimeoutFor(name string, d time.Duration) Option {
+	return func(l *launcher) {
+		l.componentTimeouts[name] = d
+	}
+}
+
+// WithObserver registers observers that receive an Event for every component call, hook list
+// and phase of the lifecycle.
+func WithObserver(observers ...Observer) Option {
+	return func(l *launcher) {
+		l.observers = append(l.observers, observers...)
+	}
+}
+
+// WithShutdownDelay waits d after the shutdown begins (readiness already reports not ready and
+// the BeforeStop hooks already ran) before stopping the components. It gives load balancers and
+// Kubernetes endpoints time to stop routing traffic to the application.
+func WithShutdownDelay(d time.Duration) Option {
+	return func(l *launcher) {
+		l.shutdownDelay = d
+	}
+}
+
 // New creates a new Launcher instance.
-func New(logger logz.Logger) Launcher {
-	return &launcher{
-		logger:     logger,
-		components: make([]Component, 0),
+func New(logger logz.Logger, opts ...Option) Launcher {
+	l := &launcher{
+		logger:            logger,
+		components:        make([]Component, 0),
+		phaseTimeouts:     make(map[Phase]time.Duration),
+		componentTimeout:  defaultComponentTimeout,
+		componentTimeouts: make(map[string]time.Duration),
+		restartPolicies:   make(map[string]RestartPolicy),
 	}
+
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	return l
 }
 
 // Append adds components to the launcher's registry.
@@ -63,65 +253,405 @@ func (l *launcher) BeforeStart(hooks ...Hook) {
 	l.onBeforeStart = append(l.onBeforeStart, hooks...)
 }
 
-// Run executes the full application lifecycle:
-// 1. OnInit for all components.
-// 2. BeforeStart hooks (Assembly/DI).
-// 3. OnStart for all components.
-// 4. Waits for termination signal.
-// 5. Graceful shutdown.
+// AfterStart registers hooks to be executed after the OnStart phase, e.g. to register the
+// service in a discovery system.
+func (l *launcher) AfterStart(hooks ...Hook) {
+	l.onAfterStart = append(l.onAfterStart, hooks...)
+}
+
+// BeforeStop registers hooks to be executed before the OnStop phase, e.g. to deregister the
+// service or stop accepting traffic.
+func (l *launcher) BeforeStop(hooks ...Hook) {
+	l.onBeforeStop = append(l.onBeforeStop, hooks...)
+}
+
+// AfterStop registers hooks to be executed after the OnStop phase, e.g. to flush telemetry.
+func (l *launcher) AfterStop(hooks ...Hook) {
+	l.onAfterStop = append(l.onAfterStop, hooks...)
+}
+
+// Run executes the full application lifecycle until SIGINT or SIGTERM is received, and
+// reloads the configuration on SIGHUP. Any failure before the application is ready is fatal
+// and terminates the process.
 func (l *launcher) Run() {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	defer cancel(nil)
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(quit)
+
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	go func() {
+		for {
+			select {
+			case s := <-quit:
+				l.logger.Info("launcher: señal de terminación recibida", "signal", s.String())
+				cancel(fmt.Errorf("señal %s", s))
+				return
+			case <-hup:
+				l.logger.Info("launcher: señal de recarga recibida", "signal", syscall.SIGHUP.String())
+				if err := l.Reload(ctx); err != nil {
+					l.logger.Error("launcher: la recarga finalizó con errores", err)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	err := l.RunContext(ctx)
+	switch {
+	case err == nil:
+	case errors.Is(err, errStartup):
+		l.logger.Fatal("launcher: fallo crítico durante el arranque", err)
+	case errors.Is(err, ErrComponentFailed):
+		l.logger.Fatal("launcher: aplicación detenida por la caída de un componente crítico", err)
+	default:
+		l.logger.Error("launcher: el apagado finalizó con errores", err)
+	}
+	_ = logz.Flush()
+}
+
+// RunContext executes the full application lifecycle:
+// 1. Builds the dependency graph of the components.
+// 2. OnInit for all components, layer by layer.
+// 3. BeforeStart hooks (Assembly/DI).
+// 4. OnStart for all components, layer by layer.
+// 5. AfterStart hooks.
+// 6. Waits for ctx to be cancelled, supervising the components that implement Supervised.
+// 7. BeforeStop hooks, the shutdown delay, graceful shutdown in reverse dependency order and
+// AfterStop hooks.
+//
+// The progress is reported by State: the launcher is ready once every step up to AfterStart
+// succeeded, and stops being ready as soon as the shutdown begins.
+//
+// Components in the same layer do not depend on each other and are handled in parallel.
+// If a step fails, the components already initialized are stopped and the error is returned.
+// If ctx is cancelled during startup, the OnInit or OnStart phase in progress gives up, the
+// remaining steps are skipped and the components already initialized are stopped gracefully.
+// A failed shutdown is reported as a *ShutdownError, and the death of a critical component as
+// ErrComponentFailed. The entries buffered by the asynchronous writers of logz are flushed
+// before returning.
+func (l *launcher) RunContext(ctx context.Context) error {
+	defer func() { _ = logz.Flush() }()
+
+	layers, err := buildLayers(l.components)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errStartup, err)
+	}
+
+	initialized := make(map[*node]bool, len(l.components))
+	l.layers = layers
+	l.setState(StateStarting)
+
 	l.logger.Info("launcher: iniciando fase de inicialización (OnInit)")
-	for _, c := range l.components {
-		if err := c.OnInit(); err != nil {
-			l.logger.Fatal("launcher: fallo crítico en OnInit", err)
+	if err := l.runPhase(ctx, layers, PhaseInit, initialized); err != nil {
+		if ctx.Err() != nil {
+			return l.cancelled(ctx, layers, initialized)
 		}
+		l.logger.Error("launcher: fallo crítico en OnInit", err)
+		return l.abort(layers, initialized, err)
+	}
+	if ctx.Err() != nil {
+		return l.cancelled(ctx, layers, initialized)
 	}
 
 	l.logger.Info("launcher: ejecutando ganchos de ensamblaje (DI)")
-	for _, hook := range l.onBeforeStart {
-		if err := hook(); err != nil {
-			l.logger.Fatal("launcher: fallo crítico en el ensamblaje de dependencias", err)
-		}
+	if err := l.runHooks(PhaseBeforeStart, l.onBeforeStart, true); err != nil {
+		l.logger.Error("launcher: fallo crítico en el ensamblaje de dependencias", err)
+		return l.abort(layers, initialized, err)
+	}
+	if ctx.Err() != nil {
+		return l.cancelled(ctx, layers, initialized)
 	}
 
 	l.logger.Info("launcher: activando componentes (OnStart)")
-	for _, c := range l.components {
-		if err := c.OnStart(); err != nil {
-			l.logger.Error("launcher: fallo en OnStart, iniciando apagado preventivo", err)
-			l.shutdown()
-			os.Exit(1)
+	if err := l.runPhase(ctx, layers, PhaseStart, nil); err != nil {
+		if ctx.Err() != nil {
+			return l.cancelled(ctx, layers, initialized)
 		}
+		l.logger.Error("launcher: fallo en OnStart, iniciando apagado preventivo", err)
+		return l.abort(layers, initialized, err)
+	}
+
+	if err := l.runHooks(PhaseAfterStart, l.onAfterStart, true); err != nil {
+		l.logger.Error("launcher: fallo en los ganchos AfterStart, iniciando apagado preventivo", err)
+		return l.abort(layers, initialized, err)
 	}
 
+	runCtx, fail := context.WithCancelCause(ctx)
+	defer fail(nil)
+	supervisor := l.supervise(layers, fail)
+
+	l.setState(StateRunning)
 	l.logger.Info("launcher: aplicación lista y operando")
 
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
-	s := <-quit
-	l.logger.Info("launcher: señal de terminación recibida", "signal", s.String())
+	<-runCtx.Done()
+	supervisor.stop()
+
+	if cause := context.Cause(runCtx); errors.Is(cause, ErrComponentFailed) {
+		l.logger.Error("launcher: componente crítico caído, iniciando apagado", cause)
+		return errors.Join(cause, l.stop(layers, nil))
+	}
+	return l.cancelled(ctx, layers, nil)
+}
 
-	l.shutdown()
+// cancelled stops the components after ctx is done. When only is not nil, components not
+// present in it are skipped.
+func (l *launcher) cancelled(ctx context.Context, layers [][]*node, only map[*node]bool) error {
+	l.logger.Info("launcher: contexto finalizado", "cause", context.Cause(ctx).Error())
+	return l.stop(layers, only)
 }
 
-// shutdown stops all components in reverse order of their registration.
-func (l *launcher) shutdown() {
+// abort stops the initialized components after a startup failure and returns the combined error.
+func (l *launcher) abort(layers [][]*node, initialized map[*node]bool, err error) error {
+	return errors.Join(fmt.Errorf("%w: %w", errStartup, err), l.stop(layers, initialized))
+}
+
+// shutdown stops all registered components in reverse dependency order. If the graph is not
+// valid, components are stopped in reverse order of their registration.
+func (l *launcher) shutdown() error {
+	layers, err := buildLayers(l.components)
+	if err != nil {
+		layers = make([][]*node, len(l.components))
+		for i, c := range l.components {
+			layers[i] = []*node{{name: componentName(c, i), component: c, index: i}}
+		}
+	}
+
+	return l.stop(layers, nil)
+}
+
+// stop runs the BeforeStop hooks, stops the components in reverse layer order, the components
+// of a layer in parallel, and runs the AfterStop hooks. When only is not nil, components not
+// present in it are skipped. Every hook and component is given a chance to run even if a
+// previous one failed.
+func (l *launcher) stop(layers [][]*node, only map[*node]bool) error {
 	l.logger.Info("launcher: iniciando apagado controlado (Graceful Shutdown)")
+	wasRunning := l.State() == StateRunning
+	l.setState(StateStopping)
+	defer l.setState(StateStopped)
+
+	var (
+		mu   sync.Mutex
+		errs []*ComponentError
+	)
+
+	if err := l.runHooks(PhaseBeforeStop, l.onBeforeStop, false); err != nil {
+		l.logger.Error("launcher: error en los ganchos BeforeStop", err)
+		errs = append(errs, &ComponentError{Phase: PhaseBeforeStop, Err: err})
+	}
+
+	if wasRunning && l.shutdownDelay > 0 {
+		l.logger.Info("launcher: esperando antes de detener los componentes", "delay", l.shutdownDelay.String())
+		time.Sleep(l.shutdownDelay)
+	}
 
-	for i := len(l.components) - 1; i >= 0; i-- {
-		done := make(chan struct{})
-		go func(c Component) {
-			if err := c.OnStop(); err != nil {
-				l.logger.Error("launcher: error durante OnStop", err)
+	// The stop phase runs after the run context is done, so it starts from a fresh context.
+	ctx, cancel := l.phaseContext(context.Background(), PhaseStop)
+	defer cancel()
+	start, hookErrs := time.Now(), len(errs)
+
+	for i := len(layers) - 1; i >= 0; i-- {
+		var wg sync.WaitGroup
+		for _, n := range layers[i] {
+			if only != nil && !only[n] {
+				continue
 			}
-			close(done)
-		}(l.components[i])
-
-		select {
-		case <-done:
-			continue
-		case <-time.After(15 * time.Second):
-			l.logger.Error("launcher: timeout alcanzado durante el OnStop de un componente", nil)
+
+			wg.Add(1)
+			go func(n *node) {
+				defer wg.Done()
+				if err := l.call(ctx, n, PhaseStop); err != nil {
+					l.logger.Error("launcher: error durante OnStop", err.Err, "component", n.name)
+					mu.Lock()
+					errs = append(errs, err)
+					mu.Unlock()
+				}
+			}(n)
 		}
+		wg.Wait()
+	}
+	cancel()
+
+	var stopErrs []error
+	for _, err := range errs[hookErrs:] {
+		stopErrs = append(stopErrs, err)
+	}
+	l.notify(Event{Phase: PhaseStop, Duration: time.Since(start), Err: errors.Join(stopErrs...)})
+
+	if err := l.runHooks(PhaseAfterStop, l.onAfterStop, false); err != nil {
+		l.logger.Error("launcher: error en los ganchos AfterStop", err)
+		errs = append(errs, &ComponentError{Phase: PhaseAfterStop, Err: err})
 	}
+
+	if len(errs) > 0 {
+		return &ShutdownError{Errors: errs}
+	}
+
 	l.logger.Info("launcher: sistema apagado correctamente")
+	return nil
+}
+
+// runPhase applies the phase to every component, layer by layer. The components of a layer run
+// in parallel and the next layer only begins once the current one succeeded. Components that
+// complete the phase are recorded in done, when it is not nil. The context of the components is
+// derived from ctx, so cancelling the run also cancels a phase in progress.
+func (l *launcher) runPhase(ctx context.Context, layers [][]*node, phase Phase, done map[*node]bool) (err error) {
+	ctx, cancel := l.phaseContext(ctx, phase)
+	defer cancel()
+
+	start := time.Now()
+	defer func() {
+		l.notify(Event{Phase: phase, Duration: time.Since(start), Err: err})
+	}()
+
+	for _, layer := range layers {
+		errs := make([]error, len(layer))
+
+		var wg sync.WaitGroup
+		for i, n := range layer {
+			wg.Add(1)
+			go func(i int, n *node) {
+				defer wg.Done()
+				if err := l.call(ctx, n, phase); err != nil {
+					errs[i] = err
+				}
+			}(i, n)
+		}
+		wg.Wait()
+
+		for i, n := range layer {
+			if errs[i] == nil && done != nil {
+				done[n] = true
+			}
+		}
+
+		if err := errors.Join(errs...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runHooks executes a list of hooks in order. When stopOnError is true, it returns at the first
+// failure; otherwise every hook runs and the errors are joined.
+func (l *launcher) runHooks(phase Phase, hooks []Hook, stopOnError bool) error {
+	if len(hooks) == 0 {
+		return nil
+	}
+
+	start := time.Now()
+	var errs []error
+	for _, hook := range hooks {
+		if err := hook(); err != nil {
+			errs = append(errs, err)
+			if stopOnError {
+				break
+			}
+		}
+	}
+
+	err := errors.Join(errs...)
+	l.notify(Event{Phase: phase, Duration: time.Since(start), Err: err})
+	return err
+}
+
+// phaseContext derives a context bounded by the configured timeout of the phase, if any.
+func (l *launcher) phaseContext(ctx context.Context, phase Phase) (context.Context, context.CancelFunc) {
+	if d := l.phaseTimeouts[phase]; d > 0 {
+		return context.WithTimeout(ctx, d)
+	}
+	return context.WithCancel(ctx)
+}
+
+// call runs a single lifecycle method of a component, giving up when the component timeout
+// elapses or ctx is done. Components are not interrupted: a call that gives up keeps running
+// in the background.
+func (l *launcher) call(ctx context.Context, n *node, phase Phase) *ComponentError {
+	timeout := l.componentTimeout
+	if d, ok := l.componentTimeouts[n.name]; ok {
+		timeout = d
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	result := make(chan error, 1)
+	go func() {
+		result <- phase.call(ctx, n.component)
+	}()
+
+	var err error
+	select {
+	case err = <-result:
+	case <-ctx.Done():
+		err = context.Cause(ctx)
+		if errors.Is(err, context.DeadlineExceeded) {
+			err = ErrTimeout
+		}
+	}
+
+	l.notify(Event{Component: n.name, Phase: phase, Duration: time.Since(start), Err: err})
+
+	if err != nil {
+		return &ComponentError{Component: n.name, Phase: phase, Err: err}
+	}
+	return nil
+}
+
+// call invokes the lifecycle method of c matching the phase.
+func (p Phase) call(ctx context.Context, c Component) error {
+	switch p {
+	case PhaseInit:
+		return c.OnInit()
+	case PhaseStart, PhaseRestart:
+		return c.OnStart()
+	case PhaseStop:
+		return c.OnStop()
+	case PhaseReload:
+		if r, ok := c.(Reloadable); ok {
+			return r.OnReload(ctx)
+		}
+		return nil
+	}
+	return fmt.Errorf("launcher: fase desconocida %q", p)
+}
+
+// Error returns the name of the component, or the phase for hooks, followed by the error.
+func (e *ComponentError) Error() string {
+	if e.Component == "" {
+		return fmt.Sprintf("%s: %v", e.Phase, e.Err)
+	}
+	return fmt.Sprintf("%s: %v", e.Component, e.Err)
+}
+
+// Unwrap returns the underlying component error.
+func (e *ComponentError) Unwrap() error {
+	return e.Err
+}
+
+// Error lists every component that failed to stop.
+func (e *ShutdownError) Error() string {
+	parts := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		parts[i] = err.Error()
+	}
+	return fmt.Sprintf("launcher: %d paso(s) del apagado fallaron: %s", len(e.Errors), strings.Join(parts, "; "))
+}
+
+// Unwrap returns the component errors, so errors.Is and errors.As can inspect them.
+func (e *ShutdownError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+	return errs
 }
@@ -2,7 +2,10 @@ package launcher
 
 import (
 	"context"
+	"errors"
 	"os"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -124,17 +127,53 @@ func TestLauncher_Run_Lifecycle(t *testing.T) {
 }
 
 func TestLauncher_ShutdownTimeout(t *testing.T) {
-	// l := New(&mockLogger{}).(*launcher)
+	l := New(&mockLogger{}, WithComponentTimeout(10*time.Millisecond)).(*launcher)
+	stuck := &blockingComponent{name: "stuck", release: make(chan struct{})}
+	defer close(stuck.release)
+	failing := &namedComponent{name: "failing", journal: &journal{}}
+	failing.stopErr = errors.New("close failed")
+	l.Append(stuck, failing)
 
-	// Component that hangs on OnStop
-	// c := &mockComponent{}
-	// We can't easily mock the hang without a channel or similar in the component
+	err := l.shutdown()
+
+	var shutdownErr *ShutdownError
+	if !errors.As(err, &shutdownErr) {
+		t.Fatalf("expected *ShutdownError, got %v", err)
+	}
+	if len(shutdownErr.Errors) != 2 {
+		t.Fatalf("expected 2 component errors, got %v", shutdownErr.Errors)
+	}
+	if !errors.Is(err, ErrTimeout) {
+		t.Error("expected the hanging component to be reported as timed out")
+	}
+	if !errors.Is(err, failing.stopErr) {
+		t.Error("expected the failing component error to be reported")
+	}
+	for _, ce := range shutdownErr.Errors {
+		if ce.Phase != PhaseStop {
+			t.Errorf("expected phase OnStop, got %s", ce.Phase)
+		}
+	}
 }
 
 type hangingComponent struct {
 	mockComponent
 }
 
+// blockingComponent is a named component whose OnStop blocks until release is closed.
+type blockingComponent struct {
+	mockComponent
+	name    string
+	release chan struct{}
+}
+
+func (b *blockingComponent) Name() string { return b.name }
+
+func (b *blockingComponent) OnStop() error {
+	<-b.release
+	return nil
+}
+
 func (h *hangingComponent) OnStop() error {
 	h.onStopCalled = true
 	time.Sleep(20 * time.Millisecond) // Smaller than the 15s timeout for test speed
@@ -152,3 +191,775 @@ func TestLauncher_ShutdownSmallDelay(t *testing.T) {
 		t.Error("OnStop was not called")
 	}
 }
+
+// namedComponent is a mockComponent with a name and dependencies that records the order of
+// lifecycle calls in a shared journal.
+type namedComponent struct {
+	mockComponent
+	name    string
+	deps    []string
+	journal *journal
+	// barrier, when set, makes OnStart wait until it is closed.
+	barrier chan struct{}
+}
+
+// journal is a concurrency-safe record of lifecycle calls.
+type journal struct {
+	mu      sync.Mutex
+	entries []string
+}
+
+func (j *journal) add(entry string) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.entries = append(j.entries, entry)
+}
+
+func (j *journal) indexOf(entry string) int {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	for i, e := range j.entries {
+		if e == entry {
+			return i
+		}
+	}
+	return -1
+}
+
+func (n *namedComponent) Name() string        { return n.name }
+func (n *namedComponent) DependsOn() []string { return n.deps }
+
+func (n *namedComponent) OnInit() error {
+	n.journal.add("init:" + n.name)
+	return n.initErr
+}
+
+func (n *namedComponent) OnStart() error {
+	if n.barrier != nil {
+		<-n.barrier
+	}
+	n.journal.add("start:" + n.name)
+	return n.startErr
+}
+
+func (n *namedComponent) OnStop() error {
+	n.journal.add("stop:" + n.name)
+	return n.stopErr
+}
+
+func TestBuildLayers(t *testing.T) {
+	j := &journal{}
+	db := &namedComponent{name: "postgres", journal: j}
+	cache := &namedComponent{name: "valkey", journal: j}
+	srv := &namedComponent{name: "http-server", deps: []string{"postgres", "valkey"}, journal: j}
+	unnamed := &mockComponent{}
+
+	layers, err := buildLayers([]Component{srv, db, unnamed, cache})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(layers) != 2 {
+		t.Fatalf("expected 2 layers, got %d", len(layers))
+	}
+	if len(layers[0]) != 3 || layers[0][0].name != "postgres" || layers[0][2].name != "valkey" {
+		t.Errorf("unexpected first layer: %v", layerNames(layers[0]))
+	}
+	if len(layers[1]) != 1 || layers[1][0].name != "http-server" {
+		t.Errorf("unexpected second layer: %v", layerNames(layers[1]))
+	}
+}
+
+func TestBuildLayers_Errors(t *testing.T) {
+	j := &journal{}
+
+	tests := []struct {
+		name       string
+		components []Component
+		contains   string
+	}{
+		{
+			name: "cycle",
+			components: []Component{
+				&namedComponent{name: "a", deps: []string{"b"}, journal: j},
+				&namedComponent{name: "b", deps: []string{"c"}, journal: j},
+				&namedComponent{name: "c", deps: []string{"a"}, journal: j},
+			},
+			contains: "a -> b -> c -> a",
+		},
+		{
+			name: "unknown dependency",
+			components: []Component{
+				&namedComponent{name: "a", deps: []string{"missing"}, journal: j},
+			},
+			contains: "missing",
+		},
+		{
+			name: "duplicate name",
+			components: []Component{
+				&namedComponent{name: "a", journal: j},
+				&namedComponent{name: "a", journal: j},
+			},
+			contains: "duplicado",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := buildLayers(tt.components)
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			if !strings.Contains(err.Error(), tt.contains) {
+				t.Errorf("expected error to contain %q, got %v", tt.contains, err)
+			}
+		})
+	}
+}
+
+func TestLauncher_DependencyOrder(t *testing.T) {
+	j := &journal{}
+	barrier := make(chan struct{})
+
+	// db and cache are independent: cache can only finish starting if db starts concurrently
+	// and closes the barrier, proving that a layer is started in parallel.
+	db := &namedComponent{name: "postgres", journal: j}
+	cache := &namedComponent{name: "valkey", journal: j, barrier: barrier}
+	srv := &namedComponent{name: "http-server", deps: []string{"postgres", "valkey"}, journal: j}
+
+	layers, err := buildLayers([]Component{srv, cache, db})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	go func() {
+		for j.indexOf("start:postgres") < 0 {
+			time.Sleep(time.Millisecond)
+		}
+		close(barrier)
+	}()
+
+	l := New(&mockLogger{}).(*launcher)
+	if err := l.runPhase(context.Background(), layers, PhaseStart, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if j.indexOf("start:http-server") < j.indexOf("start:postgres") || j.indexOf("start:http-server") < j.indexOf("start:valkey") {
+		t.Errorf("expected http-server to start after its dependencies, got %v", j.entries)
+	}
+
+	l.Append(srv, cache, db)
+	_ = l.shutdown()
+
+	if j.indexOf("stop:http-server") > j.indexOf("stop:postgres") || j.indexOf("stop:http-server") > j.indexOf("stop:valkey") {
+		t.Errorf("expected http-server to stop before its dependencies, got %v", j.entries)
+	}
+}
+
+func TestRunLayers_StopsOnError(t *testing.T) {
+	j := &journal{}
+	db := &namedComponent{name: "postgres", journal: j}
+	db.startErr = errors.New("connection refused")
+	srv := &namedComponent{name: "http-server", deps: []string{"postgres"}, journal: j}
+
+	layers, _ := buildLayers([]Component{db, srv})
+	l := New(&mockLogger{}).(*launcher)
+	err := l.runPhase(context.Background(), layers, PhaseStart, nil)
+	if err == nil || !strings.Contains(err.Error(), "postgres: connection refused") {
+		t.Fatalf("expected error naming the component, got %v", err)
+	}
+	if j.indexOf("start:http-server") >= 0 {
+		t.Error("expected dependents not to be started after a failure")
+	}
+}
+
+func layerNames(layer []*node) []string {
+	names := make([]string, 0, len(layer))
+	for _, n := range layer {
+		names = append(names, n.name)
+	}
+	return names
+}
+
+func TestLauncher_RunContext(t *testing.T) {
+	l := New(&mockLogger{})
+	c := &mockComponent{}
+	l.Append(c)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+
+	if err := l.RunContext(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !c.onInitCalled || !c.onStartCalled || !c.onStopCalled {
+		t.Errorf("expected the full lifecycle, got %+v", c)
+	}
+}
+
+func TestLauncher_RunContext_CancelledDuringStartup(t *testing.T) {
+	l := New(&mockLogger{})
+	c := &mockComponent{}
+	l.Append(c)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	l.BeforeStart(func() error {
+		cancel()
+		return nil
+	})
+
+	if err := l.RunContext(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.onStartCalled || !c.onStopCalled {
+		t.Errorf("expected the component to be stopped without starting, got %+v", c)
+	}
+}
+
+// slowStartComponent is a component whose OnStart blocks until release is closed.
+type slowStartComponent struct {
+	mockComponent
+	started chan struct{}
+	release chan struct{}
+}
+
+func (b *slowStartComponent) OnStart() error {
+	close(b.started)
+	<-b.release
+	return nil
+}
+
+func TestLauncher_RunContext_CancelledDuringStart(t *testing.T) {
+	c := &slowStartComponent{started: make(chan struct{}), release: make(chan struct{})}
+	defer close(c.release)
+
+	l := New(&mockLogger{})
+	l.Append(c)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-c.started
+		cancel()
+	}()
+
+	done := make(chan error, 1)
+	go func() { done <- l.RunContext(ctx) }()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the cancellation to interrupt OnStart")
+	}
+	if !c.onStopCalled {
+		t.Error("expected the component to be stopped")
+	}
+}
+
+func TestLauncher_RunContext_Errors(t *testing.T) {
+	t.Run("init failure stops initialized components only", func(t *testing.T) {
+		j := &journal{}
+		db := &namedComponent{name: "postgres", journal: j}
+		cache := &namedComponent{name: "valkey", journal: j}
+		cache.initErr = errors.New("unreachable")
+		srv := &namedComponent{name: "http-server", deps: []string{"postgres", "valkey"}, journal: j}
+
+		l := New(&mockLogger{})
+		l.Append(db, cache, srv)
+
+		err := l.RunContext(context.Background())
+		if !errors.Is(err, cache.initErr) {
+			t.Fatalf("expected init error, got %v", err)
+		}
+		if j.indexOf("init:http-server") >= 0 || j.indexOf("start:postgres") >= 0 {
+			t.Errorf("expected lifecycle to stop after the failure, got %v", j.entries)
+		}
+		if j.indexOf("stop:postgres") < 0 || j.indexOf("stop:valkey") >= 0 {
+			t.Errorf("expected only initialized components to be stopped, got %v", j.entries)
+		}
+	})
+
+	t.Run("hook failure", func(t *testing.T) {
+		c := &mockComponent{}
+		hookErr := errors.New("wiring failed")
+		l := New(&mockLogger{})
+		l.Append(c)
+		l.BeforeStart(func() error { return hookErr })
+
+		if err := l.RunContext(context.Background()); !errors.Is(err, hookErr) {
+			t.Fatalf("expected hook error, got %v", err)
+		}
+		if c.onStartCalled || !c.onStopCalled {
+			t.Errorf("expected component to be stopped without starting, got %+v", c)
+		}
+	})
+
+	t.Run("invalid graph", func(t *testing.T) {
+		l := New(&mockLogger{})
+		l.Append(&namedComponent{name: "a", deps: []string{"b"}, journal: &journal{}})
+
+		if err := l.RunContext(context.Background()); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+
+	t.Run("phase timeout", func(t *testing.T) {
+		slow := &slowComponent{delay: time.Second}
+		l := New(&mockLogger{}, WithStartTimeout(10*time.Millisecond))
+		l.Append(slow)
+
+		err := l.RunContext(context.Background())
+		var ce *ComponentError
+		if !errors.As(err, &ce) || ce.Phase != PhaseStart || !errors.Is(ce, ErrTimeout) {
+			t.Fatalf("expected start timeout, got %v", err)
+		}
+	})
+}
+
+func TestWithComponentTimeoutFor(t *testing.T) {
+	l := New(&mockLogger{}, WithComponentTimeout(time.Millisecond), WithComponentTimeoutFor("slow", time.Second)).(*launcher)
+	n := &node{name: "slow", component: &slowComponent{delay: 20 * time.Millisecond}}
+
+	if err := l.call(context.Background(), n, PhaseInit); err != nil {
+		t.Errorf("expected the per-component timeout to apply, got %v", err)
+	}
+}
+
+// slowComponent takes delay to complete OnInit and OnStart.
+type slowComponent struct {
+	mockComponent
+	delay time.Duration
+}
+
+func (s *slowComponent) OnInit() error {
+	time.Sleep(s.delay)
+	return nil
+}
+
+func (s *slowComponent) OnStart() error {
+	time.Sleep(s.delay)
+	return nil
+}
+
+// recordingObserver stores every event it receives.
+type recordingObserver struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func (r *recordingObserver) OnEvent(e Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, e)
+}
+
+func (r *recordingObserver) find(component string, phase Phase) (Event, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, e := range r.events {
+		if e.Component == component && e.Phase == phase {
+			return e, true
+		}
+	}
+	return Event{}, false
+}
+
+func TestLauncher_Hooks(t *testing.T) {
+	j := &journal{}
+	c := &namedComponent{name: "postgres", journal: j}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	l := New(&mockLogger{})
+	l.Append(c)
+	l.BeforeStart(func() error { j.add("before-start"); return nil })
+	l.AfterStart(func() error { j.add("after-start"); cancel(); return nil })
+	l.BeforeStop(func() error { j.add("before-stop"); return nil })
+	l.AfterStop(func() error { j.add("after-stop"); return nil })
+
+	if err := l.RunContext(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{"init:postgres", "before-start", "start:postgres", "after-start", "before-stop", "stop:postgres", "after-stop"}
+	if strings.Join(j.entries, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected %v, got %v", expected, j.entries)
+	}
+}
+
+func TestLauncher_HookErrors(t *testing.T) {
+	t.Run("after start failure aborts", func(t *testing.T) {
+		c := &mockComponent{}
+		hookErr := errors.New("registration failed")
+		l := New(&mockLogger{})
+		l.Append(c)
+		l.AfterStart(func() error { return hookErr })
+
+		if err := l.RunContext(context.Background()); !errors.Is(err, hookErr) {
+			t.Fatalf("expected hook error, got %v", err)
+		}
+		if !c.onStopCalled {
+			t.Error("expected component to be stopped")
+		}
+	})
+
+	t.Run("stop hooks are reported and do not prevent shutdown", func(t *testing.T) {
+		c := &mockComponent{}
+		beforeErr := errors.New("deregistration failed")
+		afterCalled := false
+		l := New(&mockLogger{}).(*launcher)
+		l.Append(c)
+		l.BeforeStop(func() error { return beforeErr })
+		l.AfterStop(func() error { afterCalled = true; return nil })
+
+		err := l.shutdown()
+
+		var shutdownErr *ShutdownError
+		if !errors.As(err, &shutdownErr) || !errors.Is(err, beforeErr) {
+			t.Fatalf("expected shutdown error with hook failure, got %v", err)
+		}
+		if shutdownErr.Errors[0].Phase != PhaseBeforeStop {
+			t.Errorf("expected BeforeStop phase, got %s", shutdownErr.Errors[0].Phase)
+		}
+		if !c.onStopCalled || !afterCalled {
+			t.Error("expected shutdown to continue after the hook failure")
+		}
+	})
+}
+
+func TestLauncher_Observer(t *testing.T) {
+	obs := &recordingObserver{}
+	db := &namedComponent{name: "postgres", journal: &journal{}}
+	failing := &namedComponent{name: "valkey", journal: &journal{}}
+	failing.stopErr = errors.New("close failed")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	l := New(&mockLogger{}, WithObserver(obs))
+	l.Append(db, failing)
+	l.AfterStart(func() error { cancel(); return nil })
+
+	_ = l.RunContext(ctx)
+
+	for _, phase := range []Phase{PhaseInit, PhaseStart, PhaseStop} {
+		if _, ok := obs.find("postgres", phase); !ok {
+			t.Errorf("expected component event for %s", phase)
+		}
+		if _, ok := obs.find("", phase); !ok {
+			t.Errorf("expected phase event for %s", phase)
+		}
+	}
+	if _, ok := obs.find("", PhaseAfterStart); !ok {
+		t.Error("expected hook event for AfterStart")
+	}
+
+	e, _ := obs.find("valkey", PhaseStop)
+	if !errors.Is(e.Err, failing.stopErr) {
+		t.Errorf("expected stop error in event, got %v", e.Err)
+	}
+	if e, _ := obs.find("", PhaseStop); e.Err == nil {
+		t.Error("expected the stop phase event to carry the component error")
+	}
+}
+
+func TestNewLogObserver(t *testing.T) {
+	obs := NewLogObserver(&mockLogger{})
+	obs.OnEvent(Event{Component: "postgres", Phase: PhaseInit, Duration: time.Millisecond})
+	obs.OnEvent(Event{Phase: PhaseStop, Err: ErrTimeout})
+
+	var called bool
+	ObserverFunc(func(Event) { called = true }).OnEvent(Event{})
+	if !called {
+		t.Error("expected ObserverFunc to be called")
+	}
+}
+
+func TestLauncher_State(t *testing.T) {
+	var (
+		l       = New(&mockLogger{}, WithShutdownDelay(20*time.Millisecond))
+		states  = make(map[string]State)
+		readies = make(map[string]bool)
+	)
+	record := func(step string) Hook {
+		return func() error {
+			states[step], readies[step] = l.State(), l.Ready()
+			return nil
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	l.Append(&mockComponent{})
+	l.BeforeStart(record("before-start"))
+	l.AfterStart(func() error {
+		cancel()
+		return nil
+	})
+	l.BeforeStop(record("before-stop"))
+	l.AfterStop(record("after-stop"))
+
+	if l.State() != StateIdle {
+		t.Errorf("expected IDLE before running, got %s", l.State())
+	}
+
+	start := time.Now()
+	if err := l.RunContext(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if states["before-start"] != StateStarting || readies["before-start"] {
+		t.Errorf("expected STARTING and not ready during startup, got %s", states["before-start"])
+	}
+	if states["before-stop"] != StateStopping || readies["before-stop"] {
+		t.Errorf("expected STOPPING and not ready in BeforeStop, got %s", states["before-stop"])
+	}
+	if l.State() != StateStopped {
+		t.Errorf("expected STOPPED after shutdown, got %s", l.State())
+	}
+	if !l.Started() {
+		t.Error("expected Started to stay true after a completed startup")
+	}
+	if time.Since(start) < 20*time.Millisecond {
+		t.Error("expected the shutdown delay to be applied")
+	}
+
+	t.Run("failed startup is never started", func(t *testing.T) {
+		l := New(&mockLogger{})
+		l.Append(&mockComponent{startErr: errors.New("boom")})
+
+		if err := l.RunContext(context.Background()); err == nil {
+			t.Fatal("expected a startup error")
+		}
+		if l.State() != StateStopped || l.Started() {
+			t.Errorf("expected STOPPED and not started after a failed startup, got %s", l.State())
+		}
+	})
+}
+
+// supervisedComponent is a Supervised component whose background work can be killed on demand.
+type supervisedComponent struct {
+	mockComponent
+	name   string
+	mu     sync.Mutex
+	done   chan error
+	starts int
+}
+
+func (s *supervisedComponent) Name() string { return s.name }
+
+func (s *supervisedComponent) OnStart() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.starts++
+	s.done = make(chan error, 1)
+	return nil
+}
+
+func (s *supervisedComponent) Done() <-chan error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.done
+}
+
+func (s *supervisedComponent) kill() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.done <- errors.New("loop exited")
+}
+
+func (s *supervisedComponent) startCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.starts
+}
+
+func TestLauncher_Supervision(t *testing.T) {
+	t.Run("critical component without restarts shuts down the application", func(t *testing.T) {
+		c := &supervisedComponent{name: "server"}
+		l := New(&mockLogger{}, WithRestartPolicy("server", RestartPolicy{Critical: true}))
+		l.Append(c)
+		l.AfterStart(func() error {
+			go c.kill()
+			return nil
+		})
+
+		err := l.RunContext(context.Background())
+		if !errors.Is(err, ErrComponentFailed) {
+			t.Fatalf("expected ErrComponentFailed, got %v", err)
+		}
+		if !c.onStopCalled {
+			t.Error("expected the application to be stopped")
+		}
+	})
+
+	t.Run("restarts on failure until the limit", func(t *testing.T) {
+		c := &supervisedComponent{name: "server"}
+		obs := &recordingObserver{}
+		l := New(&mockLogger{},
+			WithObserver(obs),
+			WithDefaultRestartPolicy(RestartPolicy{
+				Mode:        RestartOnFailure,
+				MaxRestarts: 2,
+				Backoff:     time.Millisecond,
+				Critical:    true,
+			}),
+		)
+		l.Append(c)
+		l.AfterStart(func() error {
+			go func() {
+				for i := 0; i < 3; i++ {
+					for c.startCount() <= i {
+						time.Sleep(time.Millisecond)
+					}
+					c.kill()
+				}
+			}()
+			return nil
+		})
+
+		err := l.RunContext(context.Background())
+		if !errors.Is(err, ErrComponentFailed) {
+			t.Fatalf("expected ErrComponentFailed after exhausting restarts, got %v", err)
+		}
+		if c.startCount() != 3 {
+			t.Errorf("expected 1 start and 2 restarts, got %d starts", c.startCount())
+		}
+		if _, ok := obs.find("server", PhaseRestart); !ok {
+			t.Error("expected restart events")
+		}
+	})
+
+	t.Run("stable component resets its restarts", func(t *testing.T) {
+		c := &supervisedComponent{name: "server"}
+		l := New(&mockLogger{},
+			WithDefaultRestartPolicy(RestartPolicy{
+				Mode:        RestartOnFailure,
+				MaxRestarts: 1,
+				Backoff:     time.Millisecond,
+				ResetAfter:  20 * time.Millisecond,
+				Critical:    true,
+			}),
+		)
+		l.Append(c)
+		l.AfterStart(func() error {
+			go func() {
+				for i := 0; i < 3; i++ {
+					for c.startCount() <= i {
+						time.Sleep(time.Millisecond)
+					}
+					if i < 2 {
+						time.Sleep(40 * time.Millisecond)
+					}
+					c.kill()
+				}
+			}()
+			return nil
+		})
+
+		err := l.RunContext(context.Background())
+		if !errors.Is(err, ErrComponentFailed) {
+			t.Fatalf("expected ErrComponentFailed after the fast failures, got %v", err)
+		}
+		if c.startCount() != 3 {
+			t.Errorf("expected the stable runs to reset the restarts, got %d starts", c.startCount())
+		}
+	})
+
+	t.Run("non critical component is left stopped", func(t *testing.T) {
+		c := &supervisedComponent{name: "server"}
+		ctx, cancel := context.WithCancel(context.Background())
+		l := New(&mockLogger{}, WithObserver(ObserverFunc(func(e Event) {
+			if e.Phase == PhaseRun {
+				cancel()
+			}
+		})))
+		l.Append(c)
+		l.AfterStart(func() error {
+			go c.kill()
+			return nil
+		})
+
+		if err := l.RunContext(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if c.startCount() != 1 {
+			t.Errorf("expected no restarts, got %d starts", c.startCount())
+		}
+	})
+}
+
+func TestNextDelay(t *testing.T) {
+	const maxBackoff = 30 * time.Second
+
+	delay := nextDelay(0, time.Second, maxBackoff)
+	if delay != time.Second {
+		t.Fatalf("expected the first delay to be the backoff, got %s", delay)
+	}
+	for i := 0; i < 100; i++ {
+		next := nextDelay(delay, time.Second, maxBackoff)
+		if next < delay || next > maxBackoff {
+			t.Fatalf("expected the delay to grow up to %s, got %s after %s", maxBackoff, next, delay)
+		}
+		delay = next
+	}
+	if delay != maxBackoff {
+		t.Errorf("expected the delay to stay at %s, got %s", maxBackoff, delay)
+	}
+	if got := nextDelay(0, time.Minute, maxBackoff); got != maxBackoff {
+		t.Errorf("expected a backoff above the maximum to be capped, got %s", got)
+	}
+}
+
+// reloadingComponent is a named component that records its reloads in a journal.
+type reloadingComponent struct {
+	namedComponent
+	reloadErr error
+}
+
+func (r *reloadingComponent) OnReload(ctx context.Context) error {
+	r.journal.add("reload:" + r.name)
+	return r.reloadErr
+}
+
+func TestLauncher_Reload(t *testing.T) {
+	j := &journal{}
+	db := &reloadingComponent{namedComponent: namedComponent{name: "postgres", journal: j}}
+	srv := &reloadingComponent{namedComponent: namedComponent{name: "http-server", deps: []string{"postgres"}, journal: j}}
+	srv.reloadErr = errors.New("invalid origins")
+	plain := &namedComponent{name: "worker", journal: j}
+
+	l := New(&mockLogger{})
+	l.Append(srv, plain, db)
+	l.AppendReloadable(ReloadableFunc(func(ctx context.Context) error {
+		j.add("reload:log-level")
+		return nil
+	}))
+
+	if err := l.Reload(context.Background()); !errors.Is(err, ErrNotRunning) {
+		t.Fatalf("expected ErrNotRunning before running, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var reloadErr error
+	l.AfterStart(func() error {
+		go func() {
+			reloadErr = l.Reload(ctx)
+			cancel()
+		}()
+		return nil
+	})
+
+	if err := l.RunContext(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !errors.Is(reloadErr, srv.reloadErr) {
+		t.Errorf("expected the component reload error, got %v", reloadErr)
+	}
+	dbIdx, srvIdx, extraIdx := j.indexOf("reload:postgres"), j.indexOf("reload:http-server"), j.indexOf("reload:log-level")
+	if dbIdx < 0 || srvIdx < dbIdx || extraIdx < srvIdx {
+		t.Errorf("expected reloads in dependency order followed by standalone reloadables, got %v", j.entries)
+	}
+}
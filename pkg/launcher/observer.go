@@ -0,0 +1,71 @@
+package launcher
+
+import (
+	"time"
+
+	"github.com/nochebuenadev/go-kit/pkg/logz"
+)
+
+type (
+	// Event describes the outcome of a lifecycle step.
+	Event struct {
+		// Component is the name of the component, or empty for events covering a whole phase
+		// or a list of hooks.
+		Component string
+		// Phase is the lifecycle phase or hook list the event belongs to.
+		Phase Phase
+		// Duration is the time the step took, or the time waited before giving up.
+		Duration time.Duration
+		// Err is the error of the step, if any. ErrTimeout marks a component that did not
+		// finish in time.
+		Err error
+	}
+
+	// Observer receives lifecycle events. Components of the same layer are handled in
+	// parallel, so OnEvent may be called concurrently.
+	Observer interface {
+		// OnEvent is called after each component call, hook list and phase completes.
+		OnEvent(e Event)
+	}
+
+	// ObserverFunc adapts a function to the Observer interface.
+	ObserverFunc func(e Event)
+
+	// logObserver is an Observer that writes every event to a logger.
+	logObserver struct {
+		// logger receives the events.
+		logger logz.Logger
+	}
+)
+
+// OnEvent calls f(e).
+func (f ObserverFunc) OnEvent(e Event) {
+	f(e)
+}
+
+// NewLogObserver returns an Observer that logs every event with its component, phase and
+// duration, at Info level on success and Error level on failure.
+func NewLogObserver(logger logz.Logger) Observer {
+	return &logObserver{logger: logger}
+}
+
+// OnEvent logs the event.
+func (o *logObserver) OnEvent(e Event) {
+	args := []any{"phase", string(e.Phase), "duration", e.Duration.String()}
+	if e.Component != "" {
+		args = append(args, "component", e.Component)
+	}
+
+	if e.Err != nil {
+		o.logger.Error("launcher: paso del ciclo de vida fallido", e.Err, args...)
+		return
+	}
+	o.logger.Info("launcher: paso del ciclo de vida completado", args...)
+}
+
+// notify sends the event to every registered observer.
+func (l *launcher) notify(e Event) {
+	for _, o := range l.observers {
+		o.OnEvent(e)
+	}
+}
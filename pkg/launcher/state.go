@@ -0,0 +1,59 @@
+package launcher
+
+// State is the lifecycle state of a Launcher.
+type State int32
+
+const (
+	// StateIdle is the state of a launcher that has not been run yet.
+	StateIdle State = iota
+	// StateStarting covers the OnInit, BeforeStart, OnStart and AfterStart steps.
+	StateStarting
+	// StateRunning means every component started and the application is serving.
+	StateRunning
+	// StateStopping begins with the BeforeStop hooks and lasts until every component stopped.
+	StateStopping
+	// StateStopped means the shutdown completed.
+	StateStopped
+)
+
+// String returns the name of the state.
+func (s State) String() string {
+	switch s {
+	case StateIdle:
+		return "IDLE"
+	case StateStarting:
+		return "STARTING"
+	case StateRunning:
+		return "RUNNING"
+	case StateStopping:
+		return "STOPPING"
+	case StateStopped:
+		return "STOPPED"
+	}
+	return "UNKNOWN"
+}
+
+// State returns the current lifecycle state. It is safe to call concurrently.
+func (l *launcher) State() State {
+	return State(l.state.Load())
+}
+
+// Started reports whether the startup sequence completed, even if the launcher is now stopping.
+// It stays false when the startup failed. It implements health.Probe.
+func (l *launcher) Started() bool {
+	return l.reachedRunning.Load()
+}
+
+// Ready reports whether the application is running and should receive traffic. It becomes
+// false as soon as the shutdown begins. It implements health.Probe.
+func (l *launcher) Ready() bool {
+	return l.State() == StateRunning
+}
+
+// setState records a lifecycle transition.
+func (l *launcher) setState(s State) {
+	if s == StateRunning {
+		l.reachedRunning.Store(true)
+	}
+	l.state.Store(int32(s))
+}
@@ -0,0 +1,203 @@
+package launcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+type (
+	// Supervised is implemented by components that run background work which may terminate
+	// unexpectedly after OnStart returned, such as a server loop or a pool of goroutines.
+	Supervised interface {
+		// Done returns a channel that receives an error when the background work of the
+		// component dies. It is read again after every restart, so components may return a
+		// new channel from each OnStart.
+		Done() <-chan error
+	}
+
+	// RestartMode selects what the launcher does when a supervised component dies.
+	RestartMode int
+
+	// RestartPolicy configures the supervision of a component.
+	RestartPolicy struct {
+		// Mode selects whether the component is restarted.
+		Mode RestartMode
+		// MaxRestarts is the number of consecutive restarts allowed. Zero means no limit.
+		MaxRestarts int
+		// Backoff is the delay before the first restart, doubled on each consecutive one
+		// (1 second by default).
+		Backoff time.Duration
+		// MaxBackoff caps the delay between restarts (30 seconds by default).
+		MaxBackoff time.Duration
+		// ResetAfter is how long a restarted component must keep running for its restarts to
+		// stop counting as consecutive, resetting the restart count and the backoff (1 minute
+		// by default).
+		ResetAfter time.Duration
+		// Critical shuts down the whole application when the component dies and is not
+		// restarted, either because of the mode or because MaxRestarts was reached.
+		Critical bool
+	}
+
+	// supervisor watches the supervised components of a run.
+	supervisor struct {
+		// launcher owns the supervised components.
+		launcher *launcher
+		// fail triggers the shutdown of the application.
+		fail context.CancelCauseFunc
+		// quit is closed when the shutdown begins, to stop the supervision.
+		quit chan struct{}
+		// wg tracks the watching goroutines.
+		wg sync.WaitGroup
+	}
+)
+
+const (
+	// RestartNever leaves a dead component stopped.
+	RestartNever RestartMode = iota
+	// RestartOnFailure restarts a dead component by calling OnStart again, with backoff.
+	RestartOnFailure
+)
+
+const (
+	// defaultBackoff is the delay before the first restart when RestartPolicy.Backoff is zero.
+	defaultBackoff = time.Second
+	// defaultMaxBackoff caps the delay between restarts when RestartPolicy.MaxBackoff is zero.
+	defaultMaxBackoff = 30 * time.Second
+	// defaultResetAfter is the stable period that resets the restarts when
+	// RestartPolicy.ResetAfter is zero.
+	defaultResetAfter = time.Minute
+)
+
+// ErrComponentFailed is reported when a critical component dies and the application is shut down.
+var ErrComponentFailed = errors.New("launcher: un componente crítico falló")
+
+// WithRestartPolicy sets the supervision policy of the component with the given name.
+func WithRestartPolicy(name string, p RestartPolicy) Option {
+	return func(l *launcher) {
+		l.restartPolicies[name] = p
+	}
+}
+
+// WithDefaultRestartPolicy sets the supervision policy of the components without a specific
+// one. By default dead components are logged and left stopped.
+func WithDefaultRestartPolicy(p RestartPolicy) Option {
+	return func(l *launcher) {
+		l.defaultRestartPolicy = p
+	}
+}
+
+// supervise starts watching every supervised component. fail is called with the cause when a
+// critical component dies.
+func (l *launcher) supervise(layers [][]*node, fail context.CancelCauseFunc) *supervisor {
+	s := &supervisor{launcher: l, fail: fail, quit: make(chan struct{})}
+
+	for _, layer := range layers {
+		for _, n := range layer {
+			sc, ok := n.component.(Supervised)
+			if !ok {
+				continue
+			}
+
+			policy, ok := l.restartPolicies[n.name]
+			if !ok {
+				policy = l.defaultRestartPolicy
+			}
+
+			s.wg.Add(1)
+			go func() {
+				defer s.wg.Done()
+				s.watch(n, sc, policy)
+			}()
+		}
+	}
+
+	return s
+}
+
+// stop ends the supervision and waits for any restart in progress.
+func (s *supervisor) stop() {
+	close(s.quit)
+	s.wg.Wait()
+}
+
+// watch waits for the component to die and applies the policy.
+func (s *supervisor) watch(n *node, sc Supervised, policy RestartPolicy) {
+	l := s.launcher
+	restarts := 0
+	var delay time.Duration
+	backoff := policy.Backoff
+	if backoff <= 0 {
+		backoff = defaultBackoff
+	}
+	maxBackoff := policy.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = defaultMaxBackoff
+	}
+	resetAfter := policy.ResetAfter
+	if resetAfter <= 0 {
+		resetAfter = defaultResetAfter
+	}
+
+	for {
+		startedAt := time.Now()
+		var err error
+		select {
+		case err = <-sc.Done():
+		case <-s.quit:
+			return
+		}
+		if time.Since(startedAt) >= resetAfter {
+			restarts, delay = 0, 0
+		}
+		if err == nil {
+			err = errors.New("finalizado inesperadamente")
+		}
+
+		l.logger.Error("launcher: el componente se detuvo inesperadamente", err, "component", n.name)
+		l.notify(Event{Component: n.name, Phase: PhaseRun, Err: err})
+
+		for {
+			if policy.Mode != RestartOnFailure || (policy.MaxRestarts > 0 && restarts >= policy.MaxRestarts) {
+				if policy.Critical {
+					s.fail(fmt.Errorf("%w: %w", ErrComponentFailed, &ComponentError{Component: n.name, Phase: PhaseRun, Err: err}))
+				}
+				return
+			}
+
+			delay = nextDelay(delay, backoff, maxBackoff)
+			restarts++
+			l.logger.Info("launcher: reiniciando componente", "component", n.name, "attempt", restarts, "delay", delay.String())
+
+			select {
+			case <-time.After(delay):
+			case <-s.quit:
+				return
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cerr := l.call(ctx, n, PhaseRestart)
+			cancel()
+			if cerr == nil {
+				break
+			}
+			err = cerr.Err
+			l.logger.Error("launcher: no se pudo reiniciar el componente", err, "component", n.name)
+		}
+	}
+}
+
+// nextDelay returns the delay before the next restart: backoff for the first one, then twice
+// the previous delay up to maxBackoff. It stops doubling before it could overflow.
+func nextDelay(prev, backoff, maxBackoff time.Duration) time.Duration {
+	switch {
+	case prev <= 0:
+		return min(backoff, maxBackoff)
+	case prev >= maxBackoff/2:
+		return maxBackoff
+	default:
+		return prev * 2
+	}
+}
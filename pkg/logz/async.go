@@ -0,0 +1,125 @@
+package logz
+
+import (
+	"bytes"
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// DefaultAsyncBufferSize is the number of entries an AsyncWriter holds when no size is given.
+const DefaultAsyncBufferSize = 1024
+
+type (
+	// AsyncWriter buffers the entries written to it and writes them to the underlying writer in
+	// the background, so slow I/O never blocks the callers. When the buffer is full new entries
+	// are dropped and counted (see Dropped) instead of waiting.
+	AsyncWriter struct {
+		// w is the underlying writer.
+		w io.Writer
+		// queue holds the pending entries.
+		queue chan asyncEntry
+		// done is closed when the background goroutine exits.
+		done chan struct{}
+		// dropped counts the entries discarded because the queue was full.
+		dropped atomic.Uint64
+		// mu guards closed and the synchronous writes made after Close.
+		mu sync.RWMutex
+		// closed reports whether Close was called.
+		closed bool
+	}
+
+	// asyncEntry is an entry to write, or a flush marker when flushed is not nil.
+	asyncEntry struct {
+		// data is the entry to write.
+		data []byte
+		// flushed is closed once every entry queued before the marker was written.
+		flushed chan struct{}
+	}
+)
+
+// NewAsyncWriter creates an AsyncWriter holding up to bufferSize entries before dropping them.
+// A bufferSize of zero or less uses DefaultAsyncBufferSize.
+func NewAsyncWriter(w io.Writer, bufferSize int) *AsyncWriter {
+	if bufferSize <= 0 {
+		bufferSize = DefaultAsyncBufferSize
+	}
+
+	a := &AsyncWriter{
+		w:     w,
+		queue: make(chan asyncEntry, bufferSize),
+		done:  make(chan struct{}),
+	}
+	go a.run()
+	return a
+}
+
+// Write queues a copy of p and returns immediately. It never fails: if the buffer is full the
+// entry is dropped and counted. After Close, entries are written synchronously.
+func (a *AsyncWriter) Write(p []byte) (int, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.closed {
+		return a.w.Write(p)
+	}
+
+	select {
+	case a.queue <- asyncEntry{data: bytes.Clone(p)}:
+	default:
+		a.dropped.Add(1)
+	}
+	return len(p), nil
+}
+
+// Flush waits until every entry queued before the call has been written.
+func (a *AsyncWriter) Flush() error {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.closed {
+		return nil
+	}
+
+	flushed := make(chan struct{})
+	a.queue <- asyncEntry{flushed: flushed}
+	<-flushed
+	return nil
+}
+
+// Close writes the pending entries and stops the background goroutine. Later entries are
+// written synchronously. The underlying writer is closed if it implements io.Closer.
+func (a *AsyncWriter) Close() error {
+	a.mu.Lock()
+	if a.closed {
+		a.mu.Unlock()
+		return nil
+	}
+	a.closed = true
+	close(a.queue)
+	a.mu.Unlock()
+
+	<-a.done
+	if c, ok := a.w.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// Dropped returns the number of entries discarded because the buffer was full.
+func (a *AsyncWriter) Dropped() uint64 {
+	return a.dropped.Load()
+}
+
+// run writes the queued entries until the queue is closed.
+func (a *AsyncWriter) run() {
+	defer close(a.done)
+
+	for e := range a.queue {
+		if e.flushed != nil {
+			close(e.flushed)
+			continue
+		}
+		_, _ = a.w.Write(e.data)
+	}
+}
@@ -5,6 +5,54 @@ It wraps the standard library's slog package to provide a consistent interface (
 that supports different log levels, structured data, context-aware logging, and
 integration with custom error types like apperr.AppErr.
 
+LogError adds the code, the public context and the internal diagnostics (WithInternal) of an
+apperr.AppErr to the entry, plus where the error was
+created: the "error_origin" group (file, line and function) and, when the full stack trace was
+recorded, "error_stack". Each logged error is also counted in the error metrics by code
+(metrics.SourceLog).
+
+The *Ctx methods (DebugCtx, InfoCtx, WarnCtx, ErrorCtx, LogErrorCtx) take the context first,
+so the request ID and the fields stored with WithRequestID, WithField and WithFields (such as
+user_id and tenant_id, set by the mw package) are logged without calling WithContext. They are
+extracted by NewContextHandler, a slog.Handler wrapper the global logger already uses.
+
+Entries are written to one or more sinks, each with its own writer, format and minimum level.
+MustInit reads them from LOG_OUTPUT ("stdout", "stderr" and "file", separated by commas); the
+"file" sink is a RotatingFile configured by LOG_FILE_PATH, LOG_FILE_LEVEL, LOG_FILE_MAX_SIZE_MB,
+LOG_FILE_MAX_AGE and LOG_FILE_MAX_BACKUPS. MustInitWith accepts the sinks in code (WithSinks).
+With LOG_ASYNC_BUFFER or WithAsync, every sink is wrapped in an AsyncWriter: entries are written
+in the background and dropped when the buffer is full, so slow I/O never blocks the callers.
+Dropped reports the discarded entries and Flush writes the pending ones; launcher calls it on
+shutdown and Fatal before exiting.
+
+Hot paths can be sampled so they don't flood the pipeline. Per level and message, only the first
+LOG_SAMPLING_FIRST records of each LOG_SAMPLING_INTERVAL (one second by default) are written, then
+one of every LOG_SAMPLING_THEREAFTER; LOG_SAMPLING_RATES keeps a random fraction per level (e.g.
+"debug=0.1,info=0.5"). WithSampling sets it in code, NewSamplingHandler applies it to any
+slog.Handler, and Suppressed reports the records that were not written.
+
+The global logger redacts sensitive data before writing it, including the fields stored in the
+context and the AppErr context copied by LogError. DefaultRedactor masks the keys apperr treats
+as sensitive (authorization, password, secret, token, api_key, cookie, session...; see
+apperr.RegisterSensitiveKeys), bearer tokens, JWTs and card numbers, and hashes emails with an
+HMAC keyed by LOG_REDACT_KEY (a random key when unset). Keys are also found inside groups and
+nested maps, and "key: value" pairs inside strings such as the HTTP dumps of httputil. A
+Redactor built with NewRedactor and the RedactKeys, RedactPattern, RedactCardNumbers and
+RedactHashKey rules chooses a Strategy per rule: Mask, Hash (a digest that keeps equal values
+correlatable) or Drop; when a key matches several rules the strictest one wins. WithRedactor
+replaces it and WithRedactor(nil) disables it; NewRedactHandler applies one to any slog.Handler
+and Redact to any string.
+
+The level of the global logger (LOG_LEVEL) can be changed at runtime with SetLevel.
+LevelReloader implements launcher.Reloadable, so the level is read again on SIGHUP.
+
+Named returns a child logger (e.g. "pgutil", "httputil", "mw") whose entries carry the "logger"
+attribute and whose level is adjusted independently with SetNamedLevel, or at startup with
+LOG_LEVELS ("pgutil=debug,httputil=warn"). A child without a level follows its parent:
+"pgutil.pool" follows "pgutil", which follows the global logger. A level set with a TTL reverts
+to the previous one when it expires, and Levels lists them all; package admin exposes them as
+Echo routes.
+
 Example usage:
 
 	logz.MustInit()
@@ -15,5 +63,41 @@ Example usage:
 	if err != nil {
 		logger.LogError("logz: fallo al procesar petición", err)
 	}
+
+	// Context-first logging: request_id, user_id, tenant_id... are added automatically
+	logger.InfoCtx(ctx, "logz: pedido creado", "order_id", id)
+
+	// Console plus a rotating file with errors only, written asynchronously
+	file, _ := logz.NewRotatingFile(logz.FileConfig{Path: "/var/log/app.log", MaxSize: 50 << 20, MaxBackups: 5})
+	logz.MustInitWith(
+		logz.WithSinks(
+			logz.Sink{Writer: os.Stdout},
+			logz.Sink{Writer: file, Level: slog.LevelError, JSON: true},
+		),
+		logz.WithAsync(4096),
+		logz.WithStaticArgs("service", "orders"),
+	)
+
+	// Custom redaction: drop national IDs, hash customer numbers
+	logz.MustInitWith(logz.WithRedactor(logz.NewRedactor(
+		logz.RedactKeys(logz.Mask, "password", "authorization"),
+		logz.RedactKeys(logz.Drop, "dni"),
+		logz.RedactPattern(logz.Hash, `CUS-\d+`),
+		logz.RedactCardNumbers(),
+	)))
+
+	// Sample repeated messages: 100 per second, then 1 in 50; a tenth of debug records
+	logz.MustInitWith(logz.WithSampling(logz.SamplingConfig{
+		First:      100,
+		Thereafter: 50,
+		Rates:      map[slog.Level]float64{slog.LevelDebug: 0.1},
+	}))
+
+	// Debug the database layer for 15 minutes only
+	db := pgutil.GetPostgresClient(logger.Named("pgutil"), dbCfg)
+	logz.SetNamedLevel("pgutil", slog.LevelDebug, 15*time.Minute)
+
+	// Reload the level on SIGHUP
+	appLauncher.AppendReloadable(logz.NewLevelReloader(nil))
 */
 package logz
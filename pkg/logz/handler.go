@@ -0,0 +1,55 @@
+package logz
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+	"slices"
+)
+
+// contextHandler is a slog.Handler that adds the request ID and the fields stored in the
+// context of each record (WithRequestID, WithField, WithFields) before passing it on.
+type contextHandler struct {
+	// next is the handler that writes the records.
+	next slog.Handler
+}
+
+// NewContextHandler wraps next so that records logged with a context (InfoCtx, slog's
+// InfoContext, etc.) get the "request_id" and the fields stored in it with WithRequestID,
+// WithField and WithFields. The global logger already uses it.
+func NewContextHandler(next slog.Handler) slog.Handler {
+	if h, ok := next.(*contextHandler); ok {
+		return h
+	}
+	return &contextHandler{next: next}
+}
+
+// Enabled implements slog.Handler.
+func (h *contextHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle implements slog.Handler.
+func (h *contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if ctx != nil {
+		if id := GetRequestID(ctx); id != "" {
+			r.AddAttrs(slog.String("request_id", id))
+		}
+		if fields, ok := ctx.Value(ctxExtraFieldsKey{}).(map[string]any); ok {
+			for _, k := range slices.Sorted(maps.Keys(fields)) {
+				r.AddAttrs(slog.Any(k, fields[k]))
+			}
+		}
+	}
+	return h.next.Handle(ctx, r)
+}
+
+// WithAttrs implements slog.Handler.
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{next: h.next.WithAttrs(attrs)}
+}
+
+// WithGroup implements slog.Handler.
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{next: h.next.WithGroup(name)}
+}
@@ -0,0 +1,76 @@
+package logz
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// LevelReloader reloads the level of the global logger. It implements launcher.Reloadable,
+// so the level can be changed on SIGHUP without restarting the application.
+type LevelReloader struct {
+	// source returns the new level name, e.g. from a reloaded configuration.
+	source func() (string, error)
+}
+
+// level is the minimum level of the global logger. It can be changed at runtime.
+var level = new(slog.LevelVar)
+
+// SetLevel changes the minimum level of the global logger and every logger derived from it,
+// except the named loggers with a level of their own. It cancels a temporary level set with
+// SetNamedLevel(RootLogger, ...).
+func SetLevel(l slog.Level) {
+	rootLevel.setLevel(levelState{level: l, set: true}, 0)
+}
+
+// GetLevel returns the current minimum level of the global logger.
+func GetLevel() slog.Level {
+	return level.Level()
+}
+
+// ParseLevel converts a level name (DEBUG, INFO, WARN or ERROR, case insensitive) to a slog.Level.
+func ParseLevel(name string) (slog.Level, error) {
+	switch strings.ToUpper(strings.TrimSpace(name)) {
+	case "DEBUG":
+		return slog.LevelDebug, nil
+	case "INFO", "":
+		return slog.LevelInfo, nil
+	case "WARN":
+		return slog.LevelWarn, nil
+	case "ERROR":
+		return slog.LevelError, nil
+	}
+	return slog.LevelInfo, fmt.Errorf("logz: nivel de log desconocido %q", name)
+}
+
+// NewLevelReloader creates a reloader that reads the level name from source on every reload.
+// If source is nil, the EnvLogLevel environment variable is read.
+func NewLevelReloader(source func() (string, error)) *LevelReloader {
+	if source == nil {
+		source = func() (string, error) {
+			return os.Getenv(EnvLogLevel), nil
+		}
+	}
+	return &LevelReloader{source: source}
+}
+
+// OnReload implements launcher.Reloadable.
+func (r *LevelReloader) OnReload(ctx context.Context) error {
+	name, err := r.source()
+	if err != nil {
+		return err
+	}
+
+	l, err := ParseLevel(name)
+	if err != nil {
+		return err
+	}
+
+	SetLevel(l)
+	return nil
+}
+
+// Name implements launcher.Named.
+func (r *LevelReloader) Name() string { return "log-level" }
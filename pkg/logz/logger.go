@@ -3,10 +3,14 @@ package logz
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
 	"os"
+	"runtime"
 	"strings"
 	"sync"
+
+	"github.com/nochebuenadev/go-kit/pkg/metrics"
 )
 
 type (
@@ -20,14 +24,30 @@ type (
 		Warn(msg string, args ...any)
 		// Error logs a message at ERROR level with an attached error.
 		Error(msg string, err error, args ...any)
-		// LogError logs an error, automatically extracting code and context if it's an apperr.AppErr.
+		// LogError logs an error, automatically extracting code, context and internal diagnostics
+		// if it's an apperr.AppErr, and counts it in the error metrics (see package metrics).
 		LogError(msg string, err error, args ...any)
 		// Fatal logs an error and exits the application with code 1.
 		Fatal(msg string, err error, args ...any)
+		// DebugCtx logs a message at DEBUG level with the request ID and fields stored in ctx.
+		DebugCtx(ctx context.Context, msg string, args ...any)
+		// InfoCtx logs a message at INFO level with the request ID and fields stored in ctx.
+		InfoCtx(ctx context.Context, msg string, args ...any)
+		// WarnCtx logs a message at WARN level with the request ID and fields stored in ctx.
+		WarnCtx(ctx context.Context, msg string, args ...any)
+		// ErrorCtx logs a message at ERROR level with an attached error and the request ID and
+		// fields stored in ctx.
+		ErrorCtx(ctx context.Context, msg string, err error, args ...any)
+		// LogErrorCtx is LogError with the request ID and fields stored in ctx.
+		LogErrorCtx(ctx context.Context, msg string, err error, args ...any)
 		// With returns a new Logger with the given attributes.
 		With(args ...any) Logger
 		// WithContext returns a new Logger that includes values from the context.
 		WithContext(ctx context.Context) Logger
+		// Named returns a child Logger whose level can be changed independently (see
+		// SetNamedLevel). Its entries carry the "logger" attribute; the names of nested children
+		// are joined with dots (e.g. "pgutil.pool").
+		Named(name string) Logger
 	}
 
 	// appErrorData is a private interface to avoid direct dependency on apperr package internals
@@ -38,10 +58,25 @@ type (
 		GetContext() map[string]any
 	}
 
+	// internalData is a private interface to extract the internal diagnostics of an error,
+	// which are logged but never sent to clients (implemented by apperr.AppErr).
+	internalData interface {
+		GetInternal() map[string]any
+	}
+
+	// errorOrigin is a private interface to extract where an error was created
+	// (implemented by apperr.AppErr).
+	errorOrigin interface {
+		Origin() runtime.Frame
+		StackTrace() []runtime.Frame
+	}
+
 	// slogLogger is the concrete implementation of Logger using slog.
 	slogLogger struct {
 		// logger is the underlying slog instance.
 		logger *slog.Logger
+		// name is the name given with Named, or empty for the global logger.
+		name string
 	}
 
 	// ctxRequestIDKey is used for request IDs in context.
@@ -67,32 +102,64 @@ var (
 // MustInit initializes the global logger once. It reads configuration from environment variables.
 // staticArgs can be used to add global fields to all logs (e.g., service name, environment).
 func MustInit(staticArgs ...any) {
+	MustInitWith(WithStaticArgs(staticArgs...))
+}
+
+// MustInitWith initializes the global logger once, like MustInit, applying opts over the
+// configuration read from environment variables. It panics if the configuration is invalid,
+// e.g. when the log file cannot be opened.
+func MustInitWith(opts ...Option) {
 	once.Do(func() {
-		level := getLogLevelFromEnv()
-		isJSON := getLogFormatFromEnv()
+		level.Set(getLogLevelFromEnv())
 
-		var handler slog.Handler
-		opts := &slog.HandlerOptions{
-			Level:     level,
-			AddSource: false,
+		levels, err := levelsFromEnv()
+		if err != nil {
+			panic(err)
+		}
+		for name, l := range levels {
+			SetNamedLevel(name, l, 0)
 		}
 
-		if isJSON {
-			handler = slog.NewJSONHandler(os.Stdout, opts)
-		} else {
-			handler = slog.NewTextHandler(os.Stdout, opts)
+		asyncBuffer, err := asyncBufferFromEnv()
+		if err != nil {
+			panic(err)
 		}
 
-		baseLogger := slog.New(handler)
+		sampling, err := samplingFromEnv()
+		if err != nil {
+			panic(err)
+		}
 
-		if len(staticArgs) > 0 {
-			baseLogger = baseLogger.With(staticArgs...)
+		o := &options{asyncBuffer: asyncBuffer, redactor: DefaultRedactor(), sampling: sampling}
+		for _, opt := range opts {
+			opt(o)
+		}
+		if o.sinks == nil {
+			if o.sinks, err = sinksFromEnv(); err != nil {
+				panic(err)
+			}
+		}
+
+		activeRedactor.Store(o.redactor)
+		baseLogger := slog.New(buildHandler(o))
+
+		if len(o.staticArgs) > 0 {
+			baseLogger = baseLogger.With(o.staticArgs...)
 		}
 
 		globalLogger = &slogLogger{logger: baseLogger}
 	})
 }
 
+// Enabled reports whether l writes entries of the level in ctx, e.g. to skip building an
+// expensive debug payload. It returns true for loggers not created by this package.
+func Enabled(ctx context.Context, l Logger, level slog.Level) bool {
+	if sl, ok := l.(*slogLogger); ok {
+		return sl.logger.Enabled(ctx, level)
+	}
+	return true
+}
+
 // Global returns the singleton logger instance. It panics if MustInit hasn't been called.
 func Global() Logger {
 	if globalLogger == nil {
@@ -142,44 +209,108 @@ func (l *slogLogger) Info(msg string, args ...any) { l.logger.Info(msg, args...)
 // Warn implements Logger.
 func (l *slogLogger) Warn(msg string, args ...any) { l.logger.Warn(msg, args...) }
 
-// Error implements Logger.
+// Error implements Logger. The error is logged as its Error() text, so errors formatting
+// extra detail with %+v (e.g. stack frames) keep a single line in the text output.
 func (l *slogLogger) Error(msg string, err error, args ...any) {
-	if err != nil {
-		args = append(args, slog.Any("error", err))
-	}
-	l.logger.Error(msg, args...)
+	l.ErrorCtx(context.Background(), msg, err, args...)
 }
 
 // LogError implements Logger.
 func (l *slogLogger) LogError(msg string, err error, args ...any) {
+	l.LogErrorCtx(context.Background(), msg, err, args...)
+}
+
+// DebugCtx implements Logger.
+func (l *slogLogger) DebugCtx(ctx context.Context, msg string, args ...any) {
+	l.logger.DebugContext(ctx, msg, args...)
+}
+
+// InfoCtx implements Logger.
+func (l *slogLogger) InfoCtx(ctx context.Context, msg string, args ...any) {
+	l.logger.InfoContext(ctx, msg, args...)
+}
+
+// WarnCtx implements Logger.
+func (l *slogLogger) WarnCtx(ctx context.Context, msg string, args ...any) {
+	l.logger.WarnContext(ctx, msg, args...)
+}
+
+// ErrorCtx implements Logger.
+func (l *slogLogger) ErrorCtx(ctx context.Context, msg string, err error, args ...any) {
+	if err != nil {
+		args = append(args, slog.String("error", err.Error()))
+	}
+	l.logger.ErrorContext(ctx, msg, args...)
+}
+
+// LogErrorCtx implements Logger.
+func (l *slogLogger) LogErrorCtx(ctx context.Context, msg string, err error, args ...any) {
 	if err == nil {
 		return
 	}
 
+	labels := metrics.ErrorLabels{Source: metrics.SourceLog}
+
 	var ae appErrorData
 	if errors.As(err, &ae) {
+		labels.Code = ae.GetCode()
 		args = append(args, slog.String("error_code", ae.GetCode()))
 
 		for k, v := range ae.GetContext() {
 			args = append(args, slog.Any(k, v))
 		}
+	}
 
-		l.Error(msg, err, args...)
-		return
+	var id internalData
+	if errors.As(err, &id) {
+		for k, v := range id.GetInternal() {
+			args = append(args, slog.Any(k, v))
+		}
 	}
 
-	l.Error(msg, err, args...)
+	var eo errorOrigin
+	if errors.As(err, &eo) {
+		args = append(args, originAttrs(eo)...)
+	}
+
+	metrics.IncError(labels)
+	l.ErrorCtx(ctx, msg, err, args...)
 }
 
-// Fatal implements Logger.
+// originAttrs returns the attributes with the origin of an error: the "error_origin" group
+// (file, line and function) and, when the full stack was recorded, "error_stack".
+func originAttrs(eo errorOrigin) []any {
+	origin := eo.Origin()
+	if origin.File == "" {
+		return nil
+	}
+
+	attrs := []any{slog.Group("error_origin",
+		slog.String("file", origin.File),
+		slog.Int("line", origin.Line),
+		slog.String("function", origin.Function),
+	)}
+
+	if trace := eo.StackTrace(); len(trace) > 1 {
+		stack := make([]string, len(trace))
+		for i, f := range trace {
+			stack[i] = fmt.Sprintf("%s %s:%d", f.Function, f.File, f.Line)
+		}
+		attrs = append(attrs, slog.Any("error_stack", stack))
+	}
+	return attrs
+}
+
+// Fatal implements Logger. The buffered entries are flushed before exiting.
 func (l *slogLogger) Fatal(msg string, err error, args ...any) {
 	l.Error(msg, err, args...)
+	_ = Flush()
 	os.Exit(1)
 }
 
 // With implements Logger.
 func (l *slogLogger) With(args ...any) Logger {
-	return &slogLogger{logger: l.logger.With(args...)}
+	return &slogLogger{logger: l.logger.With(args...), name: l.name}
 }
 
 // WithContext implements Logger.
@@ -207,21 +338,14 @@ func (l *slogLogger) WithContext(ctx context.Context) Logger {
 		return l
 	}
 
-	return &slogLogger{logger: newLogger}
+	return &slogLogger{logger: newLogger, name: l.name}
 }
 
 // getLogLevelFromEnv retrieves the log level from the EnvLogLevel environment variable.
+// Unknown values fall back to INFO.
 func getLogLevelFromEnv() slog.Level {
-	switch strings.ToUpper(os.Getenv(EnvLogLevel)) {
-	case "DEBUG":
-		return slog.LevelDebug
-	case "WARN":
-		return slog.LevelWarn
-	case "ERROR":
-		return slog.LevelError
-	default:
-		return slog.LevelInfo
-	}
+	l, _ := ParseLevel(os.Getenv(EnvLogLevel))
+	return l
 }
 
 // getLogFormatFromEnv checks if LOG_JSON_OUTPUT is set to "true" or "1".
@@ -1,9 +1,16 @@
 package logz
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"log/slog"
+	"runtime"
 	"testing"
+
+	"github.com/nochebuenadev/go-kit/pkg/metrics"
 )
 
 // mockAppErr implements the appErrorData interface for testing purposes.
@@ -113,3 +120,175 @@ func TestLoggerWithContext(t *testing.T) {
 		t.Error("WithContext(TODO) should return the original logger")
 	}
 }
+
+func TestLevel(t *testing.T) {
+	MustInit()
+	defer SetLevel(GetLevel())
+
+	if _, err := ParseLevel("verbose"); err == nil {
+		t.Error("expected error for unknown level")
+	}
+
+	SetLevel(slog.LevelError)
+	if Global().(*slogLogger).logger.Enabled(context.Background(), slog.LevelWarn) {
+		t.Error("expected WARN to be disabled after SetLevel(ERROR)")
+	}
+
+	r := NewLevelReloader(func() (string, error) { return "debug", nil })
+	if err := r.OnReload(context.Background()); err != nil {
+		t.Fatalf("OnReload failed: %v", err)
+	}
+	if !Global().(*slogLogger).logger.Enabled(context.Background(), slog.LevelDebug) {
+		t.Error("expected DEBUG to be enabled after reload")
+	}
+
+	r = NewLevelReloader(func() (string, error) { return "", errors.New("unreadable") })
+	if err := r.OnReload(context.Background()); err == nil {
+		t.Error("expected the source error to be returned")
+	}
+}
+
+// mockOriginErr implements the errorOrigin interface for testing purposes.
+type mockOriginErr struct {
+	trace []runtime.Frame
+}
+
+func (e *mockOriginErr) Error() string               { return "origin error" }
+func (e *mockOriginErr) Origin() runtime.Frame       { return e.trace[0] }
+func (e *mockOriginErr) StackTrace() []runtime.Frame { return e.trace }
+
+type mockInternalErr struct {
+	mockAppErr
+	internal map[string]any
+}
+
+func (e *mockInternalErr) GetInternal() map[string]any { return e.internal }
+
+func TestLogError_Internal(t *testing.T) {
+	var buf bytes.Buffer
+	logger := &slogLogger{logger: slog.New(slog.NewJSONHandler(&buf, nil))}
+
+	logger.LogError("failed", &mockInternalErr{
+		mockAppErr: mockAppErr{code: "ALREADY_EXISTS", message: "exists", context: map[string]any{"field": "email"}},
+		internal:   map[string]any{"constraint": "users_email_key"},
+	})
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("failed to unmarshal log entry: %v", err)
+	}
+	if entry["field"] != "email" || entry["constraint"] != "users_email_key" {
+		t.Errorf("expected public and internal data in the entry, got %v", entry)
+	}
+}
+
+func TestLogError_Origin(t *testing.T) {
+	var buf bytes.Buffer
+	logger := &slogLogger{logger: slog.New(slog.NewJSONHandler(&buf, nil))}
+
+	err := &mockOriginErr{trace: []runtime.Frame{
+		{Function: "app.(*Repo).Save", File: "/app/repo.go", Line: 42},
+		{Function: "app.(*Service).Create", File: "/app/service.go", Line: 17},
+	}}
+	logger.LogError("failed", fmt.Errorf("saving: %w", err))
+
+	var entry struct {
+		Error  string `json:"error"`
+		Origin struct {
+			File     string `json:"file"`
+			Line     int    `json:"line"`
+			Function string `json:"function"`
+		} `json:"error_origin"`
+		Stack []string `json:"error_stack"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("failed to unmarshal log entry: %v", err)
+	}
+
+	if entry.Error != "saving: origin error" {
+		t.Errorf("unexpected error attribute: %s", entry.Error)
+	}
+	if entry.Origin.File != "/app/repo.go" || entry.Origin.Line != 42 || entry.Origin.Function != "app.(*Repo).Save" {
+		t.Errorf("unexpected origin: %+v", entry.Origin)
+	}
+	if len(entry.Stack) != 2 || entry.Stack[1] != "app.(*Service).Create /app/service.go:17" {
+		t.Errorf("unexpected stack: %v", entry.Stack)
+	}
+
+	buf.Reset()
+	err.trace = err.trace[:1]
+	logger.LogError("failed", err)
+	if bytes.Contains(buf.Bytes(), []byte("error_stack")) {
+		t.Errorf("expected no stack when only the origin is recorded: %s", buf.String())
+	}
+}
+
+func TestLogError_Metrics(t *testing.T) {
+	mem := metrics.NewMemory()
+	metrics.SetErrorCounter(mem)
+	t.Cleanup(func() { metrics.SetErrorCounter(nil) })
+
+	logger := &slogLogger{logger: slog.New(slog.NewJSONHandler(&bytes.Buffer{}, nil))}
+	logger.LogError("failed", &mockAppErr{code: "NOT_FOUND", message: "missing"})
+	logger.LogError("failed", errors.New("boom"))
+	logger.LogError("nothing", nil)
+
+	report := mem.Report(metrics.SourceLog)
+	if report.Total != 2 || report.ByCode["NOT_FOUND"] != 1 || report.ByCode["INTERNAL_ERROR"] != 1 {
+		t.Errorf("unexpected report: %+v", report)
+	}
+}
+
+func TestLogger_CtxMethods(t *testing.T) {
+	var buf bytes.Buffer
+	logger := &slogLogger{logger: slog.New(NewContextHandler(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))}
+
+	ctx := WithRequestID(context.Background(), "req-1")
+	ctx = WithFields(ctx, map[string]any{"user_id": "u-1", "tenant_id": "t-1"})
+
+	logs := map[string]func(){
+		"DEBUG": func() { logger.DebugCtx(ctx, "debug") },
+		"INFO":  func() { logger.InfoCtx(ctx, "info") },
+		"WARN":  func() { logger.WarnCtx(ctx, "warn") },
+		"ERROR": func() { logger.ErrorCtx(ctx, "error", errors.New("boom")) },
+	}
+	for level, log := range logs {
+		buf.Reset()
+		log()
+
+		var entry map[string]any
+		if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+			t.Fatalf("%s: failed to unmarshal log entry: %v", level, err)
+		}
+		if entry["level"] != level || entry["request_id"] != "req-1" || entry["user_id"] != "u-1" || entry["tenant_id"] != "t-1" {
+			t.Errorf("%s: expected the context fields, got %v", level, entry)
+		}
+	}
+
+	buf.Reset()
+	logger.With("component", "test").LogErrorCtx(ctx, "failed", &mockAppErr{code: "NOT_FOUND", message: "missing"})
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("failed to unmarshal log entry: %v", err)
+	}
+	if entry["error_code"] != "NOT_FOUND" || entry["request_id"] != "req-1" || entry["component"] != "test" {
+		t.Errorf("expected the error and context fields, got %v", entry)
+	}
+
+	buf.Reset()
+	logger.Info("no context")
+	if bytes.Contains(buf.Bytes(), []byte("request_id")) {
+		t.Errorf("expected no context fields without a context, got %s", buf.String())
+	}
+}
+
+func TestEnabled(t *testing.T) {
+	logger := &slogLogger{logger: slog.New(slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{Level: slog.LevelInfo}))}
+
+	if Enabled(context.Background(), logger, slog.LevelDebug) {
+		t.Error("expected DEBUG to be disabled")
+	}
+	if !Enabled(context.Background(), logger, slog.LevelWarn) {
+		t.Error("expected WARN to be enabled")
+	}
+}
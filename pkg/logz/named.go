@@ -0,0 +1,359 @@
+package logz
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// EnvLogLevels is the environment variable with the levels of named loggers, as name=level
+	// pairs separated by commas (e.g. "pgutil=debug,httputil=warn").
+	EnvLogLevels = "LOG_LEVELS"
+
+	// RootLogger is the name that refers to the level of the global logger in SetNamedLevel,
+	// ResetNamedLevel and NamedLevel.
+	RootLogger = "root"
+
+	// allLevels is a level below every other, used by the sinks of the global logger without a
+	// level because the levelHandler on top of them already filters the records.
+	allLevels = slog.Level(math.MinInt)
+)
+
+type (
+	// LevelStatus describes the level of a logger.
+	LevelStatus struct {
+		// Name is the name of the logger, or RootLogger for the global logger.
+		Name string `json:"name"`
+		// Level is the effective minimum level.
+		Level slog.Level `json:"level"`
+		// Inherited reports whether the level is the one of the parent logger, because none was
+		// set for this one.
+		Inherited bool `json:"inherited"`
+		// ExpiresAt is when a temporary level is reverted, or zero if the level is permanent.
+		ExpiresAt time.Time `json:"expires_at,omitzero"`
+	}
+
+	// namedLevel is the adjustable level of a logger. A level that is not set follows the level
+	// of its parent: "pgutil.pool" follows "pgutil", which follows the global logger.
+	namedLevel struct {
+		// name is the name of the logger.
+		name string
+		// parent is the level followed while this one is not set, or nil for the global logger.
+		parent *namedLevel
+		// level holds the level set for the logger.
+		level *slog.LevelVar
+		// set reports whether level applies instead of the parent level.
+		set atomic.Bool
+		// mu guards gen, prev and expiresAt.
+		mu sync.Mutex
+		// gen is incremented on every change, so a stale revert does nothing.
+		gen uint64
+		// prev is the state restored when a temporary level expires.
+		prev levelState
+		// expiresAt is when the temporary level expires, or zero.
+		expiresAt time.Time
+	}
+
+	// levelState is a level and whether it is set.
+	levelState struct {
+		// level is the level set.
+		level slog.Level
+		// set reports whether the level is set or inherited.
+		set bool
+	}
+
+	// levelHandler is a slog.Handler that applies the level of a logger and adds its name. It
+	// is always the outermost handler of the loggers of this package, so Named can replace it.
+	// Records below the level of the logger still reach the sinks with a lower level of their
+	// own, marked in the context so the sinks following the logger level skip them.
+	levelHandler struct {
+		// next is the handler that writes the records.
+		next slog.Handler
+		// level is the minimum level of the logger.
+		level slog.Leveler
+		// floor is the lowest level of the sinks with a level of their own, or nil.
+		floor slog.Leveler
+		// name is the "logger" attribute added to the records, or empty.
+		name string
+	}
+
+	// loggerLevelSink is a slog.Handler for a sink without a level of its own: it skips the
+	// records below the level of the logger, which reach it only because another sink wants them.
+	loggerLevelSink struct {
+		// next is the handler of the sink.
+		next slog.Handler
+	}
+
+	// minLevel is a slog.Leveler returning the lowest of its levels.
+	minLevel []slog.Leveler
+
+	// ctxBelowLevelKey marks the records below the level of their logger.
+	ctxBelowLevelKey struct{}
+)
+
+var (
+	// rootLevel is the level of the global logger, backed by level.
+	rootLevel = newRootLevel()
+
+	// levelsMu guards namedLevels.
+	levelsMu sync.RWMutex
+	// namedLevels are the levels of the named loggers, by name.
+	namedLevels = make(map[string]*namedLevel)
+)
+
+// SetNamedLevel sets the minimum level of the named logger and of its children without a level
+// of their own. With a positive ttl the level is temporary: the previous one is restored when
+// it expires, which is handy to debug an incident in production. The logger does not need to
+// exist yet. RootLogger changes the level of the global logger.
+func SetNamedLevel(name string, l slog.Level, ttl time.Duration) {
+	levelFor(name).setLevel(levelState{level: l, set: true}, ttl)
+}
+
+// ResetNamedLevel makes the named logger follow the level of its parent again. For RootLogger,
+// the level is read again from LOG_LEVEL.
+func ResetNamedLevel(name string) {
+	if name == RootLogger {
+		rootLevel.setLevel(levelState{level: getLogLevelFromEnv(), set: true}, 0)
+		return
+	}
+	levelFor(name).setLevel(levelState{}, 0)
+}
+
+// NamedLevel returns the level of the named logger, and false if no logger with that name was
+// created with Named nor has a level set.
+func NamedLevel(name string) (LevelStatus, bool) {
+	if name == RootLogger {
+		return rootLevel.status(), true
+	}
+
+	levelsMu.RLock()
+	n, ok := namedLevels[name]
+	levelsMu.RUnlock()
+	if !ok {
+		return LevelStatus{}, false
+	}
+	return n.status(), true
+}
+
+// Levels returns the level of the global logger followed by those of the named loggers,
+// sorted by name.
+func Levels() []LevelStatus {
+	levelsMu.RLock()
+	names := make([]string, 0, len(namedLevels))
+	for name := range namedLevels {
+		names = append(names, name)
+	}
+	levelsMu.RUnlock()
+	slices.Sort(names)
+
+	statuses := make([]LevelStatus, 0, len(names)+1)
+	statuses = append(statuses, rootLevel.status())
+	for _, name := range names {
+		if status, ok := NamedLevel(name); ok {
+			statuses = append(statuses, status)
+		}
+	}
+	return statuses
+}
+
+// Named implements Logger.
+func (l *slogLogger) Named(name string) Logger {
+	if l.name != "" {
+		name = l.name + "." + name
+	}
+
+	child := &levelHandler{next: l.logger.Handler(), level: levelFor(name), name: name}
+	if lh, ok := child.next.(*levelHandler); ok {
+		child.next, child.floor = lh.next, lh.floor
+	}
+	return &slogLogger{logger: slog.New(child), name: name}
+}
+
+// Level implements slog.Leveler.
+func (n *namedLevel) Level() slog.Level {
+	if n.parent == nil || n.set.Load() {
+		return n.level.Level()
+	}
+	return n.parent.Level()
+}
+
+// setLevel applies state, restoring the current one after ttl if it is positive. Setting a
+// temporary level while another one is pending keeps the state from before the first one.
+func (n *namedLevel) setLevel(state levelState, ttl time.Duration) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	pending := !n.expiresAt.IsZero()
+	n.gen++
+	n.expiresAt = time.Time{}
+
+	if ttl > 0 {
+		if !pending {
+			n.prev = levelState{level: n.level.Level(), set: n.set.Load()}
+		}
+		n.expiresAt = time.Now().Add(ttl)
+		gen := n.gen
+		time.AfterFunc(ttl, func() { n.revert(gen) })
+	}
+
+	n.apply(state)
+}
+
+// revert restores the state from before a temporary level, unless the level changed again.
+func (n *namedLevel) revert(gen uint64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.gen != gen {
+		return
+	}
+	n.gen++
+	n.expiresAt = time.Time{}
+	n.apply(n.prev)
+}
+
+// apply stores state. The global logger always has its level set.
+func (n *namedLevel) apply(state levelState) {
+	n.level.Set(state.level)
+	n.set.Store(state.set || n.parent == nil)
+}
+
+// status returns the current level of the logger.
+func (n *namedLevel) status() LevelStatus {
+	n.mu.Lock()
+	expiresAt := n.expiresAt
+	n.mu.Unlock()
+
+	return LevelStatus{
+		Name:      n.name,
+		Level:     n.Level(),
+		Inherited: !n.set.Load(),
+		ExpiresAt: expiresAt,
+	}
+}
+
+// Enabled implements slog.Handler.
+func (h *levelHandler) Enabled(ctx context.Context, l slog.Level) bool {
+	if l < h.level.Level() && (h.floor == nil || l < h.floor.Level()) {
+		return false
+	}
+	return h.next.Enabled(ctx, l)
+}
+
+// Handle implements slog.Handler.
+func (h *levelHandler) Handle(ctx context.Context, r slog.Record) error {
+	if h.name != "" {
+		r.AddAttrs(slog.String("logger", h.name))
+	}
+	if r.Level < h.level.Level() {
+		ctx = context.WithValue(ctx, ctxBelowLevelKey{}, true)
+	}
+	return h.next.Handle(ctx, r)
+}
+
+// WithAttrs implements slog.Handler.
+func (h *levelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &levelHandler{next: h.next.WithAttrs(attrs), level: h.level, floor: h.floor, name: h.name}
+}
+
+// WithGroup implements slog.Handler.
+func (h *levelHandler) WithGroup(name string) slog.Handler {
+	return &levelHandler{next: h.next.WithGroup(name), level: h.level, floor: h.floor, name: h.name}
+}
+
+// Enabled implements slog.Handler.
+func (h *loggerLevelSink) Enabled(ctx context.Context, l slog.Level) bool {
+	return h.next.Enabled(ctx, l)
+}
+
+// Handle implements slog.Handler.
+func (h *loggerLevelSink) Handle(ctx context.Context, r slog.Record) error {
+	if below, _ := ctx.Value(ctxBelowLevelKey{}).(bool); below {
+		return nil
+	}
+	return h.next.Handle(ctx, r)
+}
+
+// WithAttrs implements slog.Handler.
+func (h *loggerLevelSink) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &loggerLevelSink{next: h.next.WithAttrs(attrs)}
+}
+
+// WithGroup implements slog.Handler.
+func (h *loggerLevelSink) WithGroup(name string) slog.Handler {
+	return &loggerLevelSink{next: h.next.WithGroup(name)}
+}
+
+// Level implements slog.Leveler.
+func (m minLevel) Level() slog.Level {
+	l := slog.Level(math.MaxInt)
+	for _, leveler := range m {
+		l = min(l, leveler.Level())
+	}
+	return l
+}
+
+// newRootLevel returns the level of the global logger.
+func newRootLevel() *namedLevel {
+	n := &namedLevel{name: RootLogger, level: level}
+	n.set.Store(true)
+	return n
+}
+
+// levelFor returns the level of the named logger, creating it and its parents if needed.
+func levelFor(name string) *namedLevel {
+	if name == RootLogger {
+		return rootLevel
+	}
+
+	levelsMu.RLock()
+	n, ok := namedLevels[name]
+	levelsMu.RUnlock()
+	if ok {
+		return n
+	}
+
+	parent := rootLevel
+	if i := strings.LastIndex(name, "."); i > 0 {
+		parent = levelFor(name[:i])
+	}
+
+	levelsMu.Lock()
+	defer levelsMu.Unlock()
+	if n, ok := namedLevels[name]; ok {
+		return n
+	}
+	n = &namedLevel{name: name, parent: parent, level: new(slog.LevelVar)}
+	namedLevels[name] = n
+	return n
+}
+
+// levelsFromEnv returns the levels of the named loggers set in LOG_LEVELS.
+func levelsFromEnv() (map[string]slog.Level, error) {
+	v := os.Getenv(EnvLogLevels)
+	if v == "" {
+		return nil, nil
+	}
+
+	levels := make(map[string]slog.Level)
+	for _, pair := range strings.Split(v, ",") {
+		name, value, ok := strings.Cut(pair, "=")
+		if name = strings.TrimSpace(name); !ok || name == "" {
+			return nil, fmt.Errorf("logz: valor inválido en %s: %q", EnvLogLevels, pair)
+		}
+		l, err := ParseLevel(value)
+		if err != nil {
+			return nil, err
+		}
+		levels[name] = l
+	}
+	return levels, nil
+}
@@ -0,0 +1,165 @@
+package logz
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNamed(t *testing.T) {
+	SetLevel(slog.LevelInfo)
+	t.Cleanup(func() { SetLevel(slog.LevelInfo) })
+
+	var buf bytes.Buffer
+	root := &slogLogger{logger: slog.New(buildHandler(&options{sinks: []Sink{{Writer: &buf}}}))}
+	child := root.Named("named-test")
+	nested := child.With("k", "v").Named("pool")
+
+	child.Debug("hidden")
+	if buf.Len() != 0 {
+		t.Fatalf("expected the child to follow the global level, got %s", buf.String())
+	}
+
+	SetNamedLevel("named-test", slog.LevelDebug, 0)
+	child.Debug("child debug")
+	nested.Debug("nested debug")
+	root.Debug("root debug")
+
+	out := buf.String()
+	if !strings.Contains(out, "logger=named-test") || !strings.Contains(out, "logger=named-test.pool") {
+		t.Errorf("expected the logger names, got %s", out)
+	}
+	if !strings.Contains(out, "nested debug") || !strings.Contains(out, "k=v") {
+		t.Errorf("expected the nested child to inherit the level, got %s", out)
+	}
+	if strings.Contains(out, "root debug") {
+		t.Error("expected the global logger to keep its level")
+	}
+
+	status, ok := NamedLevel("named-test.pool")
+	if !ok || !status.Inherited || status.Level != slog.LevelDebug {
+		t.Errorf("unexpected status: %+v", status)
+	}
+
+	ResetNamedLevel("named-test")
+	buf.Reset()
+	child.Debug("hidden again")
+	if buf.Len() != 0 {
+		t.Errorf("expected the child to follow the global level after reset, got %s", buf.String())
+	}
+}
+
+func TestSetNamedLevel_TTL(t *testing.T) {
+	SetNamedLevel("ttl-test", slog.LevelWarn, 0)
+	SetNamedLevel("ttl-test", slog.LevelDebug, 50*time.Millisecond)
+	SetNamedLevel("ttl-test", slog.LevelError, 50*time.Millisecond)
+
+	status, _ := NamedLevel("ttl-test")
+	if status.Level != slog.LevelError || status.ExpiresAt.IsZero() {
+		t.Fatalf("expected a temporary ERROR level, got %+v", status)
+	}
+
+	time.Sleep(150 * time.Millisecond)
+
+	status, _ = NamedLevel("ttl-test")
+	if status.Level != slog.LevelWarn || !status.ExpiresAt.IsZero() {
+		t.Errorf("expected the level before the temporary ones, got %+v", status)
+	}
+
+	SetNamedLevel("ttl-test", slog.LevelDebug, 50*time.Millisecond)
+	SetNamedLevel("ttl-test", slog.LevelInfo, 0)
+	time.Sleep(150 * time.Millisecond)
+	if status, _ = NamedLevel("ttl-test"); status.Level != slog.LevelInfo {
+		t.Errorf("expected a permanent level to cancel the revert, got %+v", status)
+	}
+}
+
+func TestLevels(t *testing.T) {
+	SetNamedLevel("levels-test", slog.LevelError, 0)
+
+	levels := Levels()
+	if len(levels) == 0 || levels[0].Name != RootLogger {
+		t.Fatalf("expected the global logger first, got %+v", levels)
+	}
+
+	found := false
+	for _, l := range levels {
+		if l.Name == "levels-test" {
+			found = l.Level == slog.LevelError && !l.Inherited
+		}
+	}
+	if !found {
+		t.Errorf("expected levels-test at ERROR, got %+v", levels)
+	}
+
+	if _, ok := NamedLevel("does-not-exist"); ok {
+		t.Error("expected an unknown logger not to be found")
+	}
+}
+
+func TestLevelsFromEnv(t *testing.T) {
+	t.Setenv(EnvLogLevels, "pgutil=debug, httputil=WARN")
+
+	levels, err := levelsFromEnv()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if levels["pgutil"] != slog.LevelDebug || levels["httputil"] != slog.LevelWarn {
+		t.Errorf("unexpected levels: %v", levels)
+	}
+
+	t.Setenv(EnvLogLevels, "pgutil")
+	if _, err := levelsFromEnv(); err == nil {
+		t.Error("expected an error for a pair without level")
+	}
+}
+
+func TestNamed_SingleLoggerAttr(t *testing.T) {
+	var buf bytes.Buffer
+	root := &slogLogger{logger: slog.New(buildHandler(&options{sinks: []Sink{{Writer: &buf, JSON: true}}}))}
+
+	root.Named("attr-test").With("k", "v").Named("pool").Warn("ok")
+
+	if got := strings.Count(buf.String(), `"logger"`); got != 1 {
+		t.Errorf("expected a single logger key, got %d in %s", got, buf.String())
+	}
+	if !strings.Contains(buf.String(), `"logger":"attr-test.pool"`) || !strings.Contains(buf.String(), `"k":"v"`) {
+		t.Errorf("unexpected entry: %s", buf.String())
+	}
+}
+
+func TestNamed_SinkLevels(t *testing.T) {
+	SetLevel(slog.LevelInfo)
+	t.Cleanup(func() { SetLevel(slog.LevelInfo) })
+
+	t.Setenv(EnvLogLevels, "sink-test=warn")
+	levels, err := levelsFromEnv()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for name, l := range levels {
+		SetNamedLevel(name, l, 0)
+	}
+
+	var console, file bytes.Buffer
+	root := &slogLogger{logger: slog.New(buildHandler(&options{sinks: []Sink{
+		{Writer: &console},
+		{Writer: &file, Level: slog.LevelDebug},
+	}}))}
+	child := root.Named("sink-test")
+
+	root.Debug("root debug")
+	child.Info("child info")
+	child.Warn("child warn")
+
+	if out := console.String(); strings.Contains(out, "root debug") || strings.Contains(out, "child info") ||
+		!strings.Contains(out, "child warn") {
+		t.Errorf("expected the console to follow the logger levels, got %s", out)
+	}
+	if out := file.String(); !strings.Contains(out, "root debug") || !strings.Contains(out, "child info") ||
+		!strings.Contains(out, "child warn") {
+		t.Errorf("expected the DEBUG sink to get every record, got %s", out)
+	}
+}
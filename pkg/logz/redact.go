@@ -0,0 +1,413 @@
+package logz
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"os"
+	"regexp"
+	"strings"
+	"sync/atomic"
+
+	"github.com/nochebuenadev/go-kit/internal/sensitive"
+)
+
+const (
+	// Mask replaces the value with RedactedValue.
+	Mask Strategy = iota
+	// Hash replaces the value with a short HMAC-SHA256 digest ("hmac:…") keyed with
+	// RedactHashKey, so equal values can still be correlated across entries without being
+	// revealed, nor guessed from a list of candidates by whoever lacks the key.
+	Hash
+	// Drop removes the attribute. Inside text, where nothing can be removed, it behaves as Mask.
+	Drop
+)
+
+const (
+	// EnvLogRedactKey is the environment variable with the key of the digests made by the Hash
+	// strategy of DefaultRedactor. Without it a random key is used, so the digests only match
+	// within the same process.
+	EnvLogRedactKey = "LOG_REDACT_KEY"
+
+	// RedactedValue replaces the values redacted with Mask.
+	RedactedValue = sensitive.RedactedValue
+	// hashPrefix marks the values redacted with Hash.
+	hashPrefix = "hmac:"
+)
+
+type (
+	// Strategy is how a Redactor hides a sensitive value.
+	Strategy int
+
+	// RedactRule configures a Redactor created with NewRedactor.
+	RedactRule func(*Redactor)
+
+	// Redactor hides sensitive data in log entries. Attributes are matched by key name, split into
+	// words on separators and camelCase and ignoring case (so "X-Api-Key" matches "api_key" but
+	// "max_tokens" does not match "token"), including the keys of nested groups and maps.
+	// Strings, messages and error texts are matched by regular expression, and also scanned for
+	// "key: value", "key=value" and "key":"value" pairs of the sensitive keys, which covers dumped
+	// HTTP headers and JSON bodies; unquoted values end at whitespace. When a key matches several
+	// rules, the strictest strategy wins: Drop, then Mask, then Hash. A Redactor must not be
+	// modified once in use.
+	Redactor struct {
+		// keys are the sensitive key rules, in the order they were added.
+		keys []redactKey
+		// patterns are the value patterns, applied in order.
+		patterns []redactPattern
+		// hashKey is the key of the Hash digests.
+		hashKey []byte
+	}
+
+	// redactKey is a sensitive key fragment with its strategy.
+	redactKey struct {
+		// fragment is the normalized key fragment.
+		fragment string
+		// strategy is how the values of the matching keys are hidden.
+		strategy Strategy
+	}
+
+	// redactPattern is a value pattern with its strategy.
+	redactPattern struct {
+		// re matches the sensitive values.
+		re *regexp.Regexp
+		// strategy is how the matches are hidden.
+		strategy Strategy
+		// valid, if set, confirms a match before it is redacted (e.g. a Luhn check).
+		valid func(string) bool
+	}
+
+	// redactHandler is a slog.Handler that redacts the records before passing them on.
+	redactHandler struct {
+		// next is the handler that writes the records.
+		next slog.Handler
+		// r is the redactor applied to the records.
+		r *Redactor
+	}
+)
+
+var (
+	// cardPattern matches the usual formats of payment card numbers (13 to 19 digits).
+	cardPattern = regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`)
+
+	// pairPattern matches "key: value", "key=value" and "key":"value" pairs, capturing the key
+	// with its separator, the key and the value. Unquoted values end at whitespace or at the
+	// next delimiter, except for the scheme of an Authorization header ("Bearer abc").
+	pairPattern = regexp.MustCompile(
+		`(?i)("?([\w.-]+)"?\s*[:=]\s*)("[^"]*"|(?:(?:bearer|basic|digest|negotiate)\s+)?[^\s",;&}]+)`,
+	)
+
+	// activeRedactor is the redactor of the global logger, used by Redact.
+	activeRedactor atomic.Pointer[Redactor]
+)
+
+func init() {
+	activeRedactor.Store(DefaultRedactor())
+}
+
+// RedactKeys redacts the attributes whose key spells any of keys in whole words with the
+// strategy, e.g. "password" matches "db_password" and "dbPassword" but not "passwordless".
+func RedactKeys(strategy Strategy, keys ...string) RedactRule {
+	return func(r *Redactor) {
+		for _, k := range keys {
+			if n := sensitive.NormalizeKey(k); n != "" {
+				r.keys = append(r.keys, redactKey{fragment: n, strategy: strategy})
+			}
+		}
+	}
+}
+
+// RedactPattern redacts the substrings of strings matching the regular expression with the
+// strategy. It panics if pattern does not compile.
+func RedactPattern(strategy Strategy, pattern string) RedactRule {
+	re := regexp.MustCompile(pattern)
+	return func(r *Redactor) {
+		r.patterns = append(r.patterns, redactPattern{re: re, strategy: strategy})
+	}
+}
+
+// RedactCardNumbers masks the numbers that look like payment cards and pass the Luhn check.
+func RedactCardNumbers() RedactRule {
+	return func(r *Redactor) {
+		r.patterns = append(r.patterns, redactPattern{re: cardPattern, strategy: Mask, valid: luhn})
+	}
+}
+
+// RedactHashKey sets the key of the digests made by the Hash strategy. Without it, NewRedactor
+// uses a random key, so the digests only match within the same process.
+func RedactHashKey(key []byte) RedactRule {
+	return func(r *Redactor) {
+		r.hashKey = key
+	}
+}
+
+// NewRedactor creates a Redactor with the given rules.
+func NewRedactor(rules ...RedactRule) *Redactor {
+	r := &Redactor{}
+	for _, rule := range rules {
+		rule(r)
+	}
+	if len(r.hashKey) == 0 {
+		r.hashKey = make([]byte, 32)
+		_, _ = rand.Read(r.hashKey)
+	}
+	return r
+}
+
+// DefaultRedactor returns the Redactor used by the global logger unless WithRedactor says
+// otherwise. It masks the keys apperr treats as sensitive (authorization, password, secret,
+// token, api_key, cookie, session...; see apperr.RegisterSensitiveKeys, which must be called
+// before the logger is initialized), bearer tokens, JWTs and card numbers, and hashes emails
+// with the key in LOG_REDACT_KEY.
+func DefaultRedactor() *Redactor {
+	return NewRedactor(
+		RedactKeys(Mask, sensitive.Keys()...),
+		RedactKeys(Hash, "email"),
+		RedactHashKey([]byte(os.Getenv(EnvLogRedactKey))),
+		RedactPattern(Mask, `(?i)\bbearer\s+[a-z0-9\-._~+/]+=*`),
+		RedactPattern(Mask, `\beyJ[\w-]+\.[\w-]+\.[\w-]+`),
+		RedactPattern(Hash, `[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}`),
+		RedactCardNumbers(),
+	)
+}
+
+// Redact returns s with the sensitive data hidden by the redactor of the global logger, e.g.
+// to sanitize a dump before logging it with a custom Logger.
+func Redact(s string) string {
+	if r := activeRedactor.Load(); r != nil {
+		return r.RedactString(s)
+	}
+	return s
+}
+
+// NewRedactHandler wraps next so that the message and attributes of every record, including
+// the attributes added with With, are redacted by r.
+func NewRedactHandler(next slog.Handler, r *Redactor) slog.Handler {
+	return &redactHandler{next: next, r: r}
+}
+
+// RedactString returns s with the sensitive values and "key: value" pairs hidden.
+func (r *Redactor) RedactString(s string) string {
+	s = r.redactPairs(s)
+
+	for _, p := range r.patterns {
+		s = p.re.ReplaceAllStringFunc(s, func(m string) string {
+			if p.valid != nil && !p.valid(m) {
+				return m
+			}
+			return r.hide(m, p.strategy)
+		})
+	}
+	return s
+}
+
+// Attr returns the attribute with its sensitive data hidden, and false if it must be dropped.
+func (r *Redactor) Attr(a slog.Attr) (slog.Attr, bool) {
+	a.Value = a.Value.Resolve()
+
+	if strategy, ok := r.keyStrategy(a.Key); ok {
+		if strategy == Drop {
+			return a, false
+		}
+		return slog.String(a.Key, r.hide(valueString(a.Value), strategy)), true
+	}
+
+	switch a.Value.Kind() {
+	case slog.KindGroup:
+		attrs := make([]slog.Attr, 0, len(a.Value.Group()))
+		for _, ga := range a.Value.Group() {
+			if ga, ok := r.Attr(ga); ok {
+				attrs = append(attrs, ga)
+			}
+		}
+		return slog.Attr{Key: a.Key, Value: slog.GroupValue(attrs...)}, true
+	case slog.KindString:
+		return slog.String(a.Key, r.RedactString(a.Value.String())), true
+	case slog.KindAny:
+		return slog.Any(a.Key, r.value(a.Value.Any())), true
+	default:
+		return a, true
+	}
+}
+
+// value returns v with its sensitive data hidden. Maps and slices are walked; errors are
+// replaced by their redacted text.
+func (r *Redactor) value(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		redacted := make(map[string]any, len(v))
+		for k, e := range v {
+			if strategy, ok := r.keyStrategy(k); ok {
+				if strategy != Drop {
+					redacted[k] = r.hide(fmt.Sprint(e), strategy)
+				}
+				continue
+			}
+			redacted[k] = r.value(e)
+		}
+		return redacted
+	case map[string]string:
+		redacted := make(map[string]string, len(v))
+		for k, e := range v {
+			if strategy, ok := r.keyStrategy(k); ok {
+				if strategy != Drop {
+					redacted[k] = r.hide(e, strategy)
+				}
+				continue
+			}
+			redacted[k] = r.RedactString(e)
+		}
+		return redacted
+	case []any:
+		redacted := make([]any, len(v))
+		for i, e := range v {
+			redacted[i] = r.value(e)
+		}
+		return redacted
+	case []string:
+		redacted := make([]string, len(v))
+		for i, e := range v {
+			redacted[i] = r.RedactString(e)
+		}
+		return redacted
+	case string:
+		return r.RedactString(v)
+	case error:
+		return r.RedactString(v.Error())
+	default:
+		return v
+	}
+}
+
+// redactPairs hides the values of the "key: value" pairs of s whose key is sensitive. The
+// values of the other pairs are scanned as well, e.g. the query of "url=/login?token=abc".
+func (r *Redactor) redactPairs(s string) string {
+	return pairPattern.ReplaceAllStringFunc(s, func(m string) string {
+		sub := pairPattern.FindStringSubmatch(m)
+		prefix, value := sub[1], sub[3]
+
+		strategy, ok := r.keyStrategy(sub[2])
+		if !ok {
+			return prefix + r.redactPairs(value)
+		}
+		if quoted := strings.HasPrefix(value, `"`); quoted {
+			return prefix + `"` + r.hide(strings.Trim(value, `"`), strategy) + `"`
+		}
+		return prefix + r.hide(value, strategy)
+	})
+}
+
+// keyStrategy returns the strictest strategy of the sensitive key fragments spelled by the
+// words of key (see sensitive.Match).
+func (r *Redactor) keyStrategy(key string) (Strategy, bool) {
+	segments := sensitive.Split(key)
+	if len(segments) == 0 {
+		return 0, false
+	}
+
+	var strategy Strategy
+	found := false
+	for _, k := range r.keys {
+		if sensitive.Match(segments, k.fragment) && (!found || k.strategy.stricter(strategy)) {
+			strategy, found = k.strategy, true
+		}
+	}
+	return strategy, found
+}
+
+// hide applies the strategy to a sensitive value. Values that are already redacted are kept, so
+// redacting twice gives the same result.
+func (r *Redactor) hide(value string, strategy Strategy) string {
+	if value == RedactedValue {
+		return value
+	}
+	if strategy != Hash {
+		return RedactedValue
+	}
+	if strings.HasPrefix(value, hashPrefix) {
+		return value
+	}
+	mac := hmac.New(sha256.New, r.hashKey)
+	mac.Write([]byte(value))
+	return hashPrefix + hex.EncodeToString(mac.Sum(nil)[:8])
+}
+
+// stricter reports whether s hides more than other: Drop, then Mask, then Hash.
+func (s Strategy) stricter(other Strategy) bool {
+	rank := func(s Strategy) int {
+		switch s {
+		case Drop:
+			return 2
+		case Mask:
+			return 1
+		default:
+			return 0
+		}
+	}
+	return rank(s) > rank(other)
+}
+
+// Enabled implements slog.Handler.
+func (h *redactHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle implements slog.Handler.
+func (h *redactHandler) Handle(ctx context.Context, r slog.Record) error {
+	redacted := slog.NewRecord(r.Time, r.Level, h.r.RedactString(r.Message), r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		if a, ok := h.r.Attr(a); ok {
+			redacted.AddAttrs(a)
+		}
+		return true
+	})
+	return h.next.Handle(ctx, redacted)
+}
+
+// WithAttrs implements slog.Handler.
+func (h *redactHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, 0, len(attrs))
+	for _, a := range attrs {
+		if a, ok := h.r.Attr(a); ok {
+			redacted = append(redacted, a)
+		}
+	}
+	return &redactHandler{next: h.next.WithAttrs(redacted), r: h.r}
+}
+
+// WithGroup implements slog.Handler.
+func (h *redactHandler) WithGroup(name string) slog.Handler {
+	return &redactHandler{next: h.next.WithGroup(name), r: h.r}
+}
+
+// valueString returns the text of a value to hide it.
+func valueString(v slog.Value) string {
+	if v.Kind() == slog.KindAny {
+		return fmt.Sprint(v.Any())
+	}
+	return v.String()
+}
+
+// luhn reports whether the digits of s pass the Luhn checksum used by payment cards.
+func luhn(s string) bool {
+	sum, double := 0, false
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		d := int(c - '0')
+		if double {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
@@ -0,0 +1,188 @@
+package logz
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestRedactor_RedactString(t *testing.T) {
+	r := DefaultRedactor()
+
+	tests := []struct {
+		name    string
+		in      string
+		leaked  string
+		contain string
+	}{
+		{"authorization header", "Authorization: Bearer abc.def\r\n", "abc.def", "Authorization: " + RedactedValue},
+		{"basic auth header", "Authorization: Basic dXNlcjpwYXNz\r\n", "dXNlcjpwYXNz", "Authorization: " + RedactedValue},
+		{"json password", `{"user":"ana","password":"s3cr3t"}`, "s3cr3t", `"password":"` + RedactedValue + `"`},
+		{"api key header", "X-Api-Key: k-123\r\n", "k-123", RedactedValue},
+		{"query token", "/login?token=abc&page=2", "abc", "page=2"},
+		{"bearer in text", "sent bearer xyz789 upstream", "xyz789", RedactedValue},
+		{"email", "contact ana@example.com today", "ana@example.com", hashPrefix},
+		{"card number", "paid with 4111 1111 1111 1111", "4111 1111 1111 1111", RedactedValue},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := r.RedactString(tt.in)
+			if strings.Contains(got, tt.leaked) {
+				t.Errorf("expected %q to be redacted, got %q", tt.leaked, got)
+			}
+			if !strings.Contains(got, tt.contain) {
+				t.Errorf("expected %q in %q", tt.contain, got)
+			}
+		})
+	}
+
+	t.Run("several pairs on one line", func(t *testing.T) {
+		in := "business_name=Acme order=42 address_number=12 password=s3cr3t max_tokens=100"
+		want := "business_name=Acme order=42 address_number=12 password=" + RedactedValue + " max_tokens=100"
+		if got := r.RedactString(in); got != want {
+			t.Errorf("expected %q, got %q", want, got)
+		}
+	})
+
+	t.Run("pair inside the value of another", func(t *testing.T) {
+		got := r.RedactString("url=/login?token=abc&page=2")
+		if want := "url=/login?token=" + RedactedValue + "&page=2"; got != want {
+			t.Errorf("expected %q, got %q", want, got)
+		}
+	})
+
+	t.Run("keeps numbers that are not cards", func(t *testing.T) {
+		in := "order 1234567890123456"
+		if got := r.RedactString(in); got != in {
+			t.Errorf("expected %q unchanged, got %q", in, got)
+		}
+	})
+
+	t.Run("is idempotent", func(t *testing.T) {
+		once := r.RedactString(`{"email":"ana@example.com"}`)
+		if twice := r.RedactString(once); twice != once {
+			t.Errorf("expected %q after redacting twice, got %q", once, twice)
+		}
+	})
+}
+
+func TestRedactHandler(t *testing.T) {
+	var buf bytes.Buffer
+	r := NewRedactor(
+		RedactKeys(Mask, "password"),
+		RedactKeys(Hash, "email"),
+		RedactKeys(Drop, "ssn"),
+		RedactPattern(Mask, `tok_[a-z0-9]+`),
+	)
+	logger := slog.New(NewRedactHandler(slog.NewJSONHandler(&buf, nil), r)).With("db_password", "p1")
+
+	logger.Info("login tok_abc1",
+		"user_email", "ana@example.com",
+		"ssn", "123-45-6789",
+		slog.Group("req", slog.String("Password", "p2")),
+		"details", map[string]any{"password": "p3", "nested": map[string]any{"ssn": "x", "note": "tok_zz9"}},
+		"err", errors.New("invalid tok_def2"),
+	)
+
+	out := buf.String()
+	for _, leaked := range []string{"p1", "p2", "p3", "ana@example.com", "123-45-6789", "tok_abc1", "tok_zz9", "tok_def2"} {
+		if strings.Contains(out, leaked) {
+			t.Errorf("expected %q to be redacted, got %s", leaked, out)
+		}
+	}
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if _, ok := entry["ssn"]; ok {
+		t.Error("expected ssn to be dropped")
+	}
+	if got, _ := entry["user_email"].(string); !strings.HasPrefix(got, hashPrefix) {
+		t.Errorf("expected user_email to be hashed, got %q", got)
+	}
+	nested := entry["details"].(map[string]any)["nested"].(map[string]any)
+	if _, ok := nested["ssn"]; ok {
+		t.Error("expected nested ssn to be dropped")
+	}
+}
+
+func TestBuildHandler_Redaction(t *testing.T) {
+	t.Run("redacts context fields by default", func(t *testing.T) {
+		var buf bytes.Buffer
+		o := &options{sinks: []Sink{{Writer: &buf, JSON: true}}, redactor: DefaultRedactor()}
+		logger := slog.New(buildHandler(o))
+
+		ctx := WithField(context.Background(), "token", "t-1")
+		logger.InfoContext(ctx, "ok", "authorization", "Basic Zm9v")
+
+		if out := buf.String(); strings.Contains(out, "t-1") || strings.Contains(out, "Zm9v") {
+			t.Errorf("expected the entry to be redacted, got %s", out)
+		}
+	})
+
+	t.Run("nil redactor disables it", func(t *testing.T) {
+		var buf bytes.Buffer
+		o := &options{sinks: []Sink{{Writer: &buf, JSON: true}}}
+		WithRedactor(nil)(o)
+		slog.New(buildHandler(o)).Info("ok", "password", "p1")
+
+		if !strings.Contains(buf.String(), "p1") {
+			t.Errorf("expected the entry unchanged, got %s", buf.String())
+		}
+	})
+}
+
+func TestRedactor_StrictestRule(t *testing.T) {
+	r := NewRedactor(
+		RedactKeys(Hash, "email"),
+		RedactKeys(Mask, "password"),
+		RedactKeys(Drop, "ssn"),
+	)
+
+	for i := 0; i < 20; i++ {
+		if a, ok := r.Attr(slog.String("email_password", "p1")); !ok || a.Value.String() != RedactedValue {
+			t.Fatalf("expected email_password to be masked, got %v", a)
+		}
+		if _, ok := r.Attr(slog.String("email_ssn", "x")); ok {
+			t.Fatal("expected email_ssn to be dropped")
+		}
+		if got := r.RedactString(`{"email_password":"p1"}`); got != `{"email_password":"`+RedactedValue+`"}` {
+			t.Fatalf("expected the pair to be masked, got %s", got)
+		}
+	}
+}
+
+func TestRedactor_HashKey(t *testing.T) {
+	a := NewRedactor(RedactKeys(Hash, "email"), RedactHashKey([]byte("k1")))
+	b := NewRedactor(RedactKeys(Hash, "email"), RedactHashKey([]byte("k1")))
+	c := NewRedactor(RedactKeys(Hash, "email"), RedactHashKey([]byte("k2")))
+
+	ha, _ := a.Attr(slog.String("email", "ana@example.com"))
+	hb, _ := b.Attr(slog.String("email", "ana@example.com"))
+	hc, _ := c.Attr(slog.String("email", "ana@example.com"))
+
+	if ha.Value.String() != hb.Value.String() {
+		t.Error("expected the same key to give the same digest")
+	}
+	if ha.Value.String() == hc.Value.String() {
+		t.Error("expected different keys to give different digests")
+	}
+	if !strings.HasPrefix(ha.Value.String(), hashPrefix) {
+		t.Errorf("expected the %s prefix, got %s", hashPrefix, ha.Value.String())
+	}
+}
+
+func TestDefaultRedactor_SharedKeys(t *testing.T) {
+	r := DefaultRedactor()
+	for _, key := range []string{"session_id", "ssn", "passwd"} {
+		if a, _ := r.Attr(slog.String(key, "v")); a.Value.String() != RedactedValue {
+			t.Errorf("expected %s to be masked like in apperr, got %v", key, a)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package logz
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+// backupTimeFormat is the timestamp appended to the name of rotated files. It sorts
+// chronologically.
+const backupTimeFormat = "20060102T150405.000"
+
+type (
+	// FileConfig configures a RotatingFile.
+	FileConfig struct {
+		// Path is the file the entries are written to. Its directory is created if needed.
+		Path string
+		// MaxSize is the size in bytes that triggers a rotation. Zero disables rotation.
+		MaxSize int64
+		// MaxAge is how long rotated files are kept. Zero keeps them regardless of their age.
+		MaxAge time.Duration
+		// MaxBackups is the number of rotated files kept. Zero keeps all of them.
+		MaxBackups int
+	}
+
+	// RotatingFile is an io.WriteCloser that appends to a file and rotates it when it reaches
+	// FileConfig.MaxSize: the file is renamed with a timestamp suffix (e.g. app.log.20260102T150405.000)
+	// and a new one is created. Rotated files beyond MaxBackups or older than MaxAge are removed.
+	RotatingFile struct {
+		// cfg is the file configuration.
+		cfg FileConfig
+		// mu guards file, size and closed.
+		mu sync.Mutex
+		// file is the file being written, or nil if it could not be reopened.
+		file *os.File
+		// size is the current size of file.
+		size int64
+		// closed reports whether Close was called.
+		closed bool
+	}
+)
+
+// NewRotatingFile opens, or creates, the file at cfg.Path for appending.
+func NewRotatingFile(cfg FileConfig) (*RotatingFile, error) {
+	r := &RotatingFile{cfg: cfg}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Write implements io.Writer. The file is rotated first if p would make it exceed MaxSize. If
+// a failed rotation left no file open, it is opened again.
+func (r *RotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return 0, os.ErrClosed
+	}
+	if r.file == nil {
+		if err := r.open(); err != nil {
+			return 0, err
+		}
+	}
+
+	if r.cfg.MaxSize > 0 && r.size > 0 && r.size+int64(len(p)) > r.cfg.MaxSize {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// Rotate rotates the file immediately, e.g. on demand from an admin endpoint.
+func (r *RotatingFile) Rotate() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rotate()
+}
+
+// Close implements io.Closer.
+func (r *RotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.closed = true
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+// open opens the file for appending and records its size.
+func (r *RotatingFile) open() error {
+	if err := os.MkdirAll(filepath.Dir(r.cfg.Path), 0o755); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(r.cfg.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	r.file = f
+	r.size = info.Size()
+	return nil
+}
+
+// rotate renames the current file, opens a new one and removes the expired backups. If the
+// rename fails, the current file is opened again so writing goes on.
+func (r *RotatingFile) rotate() error {
+	if r.closed {
+		return os.ErrClosed
+	}
+	if r.file != nil {
+		if err := r.file.Close(); err != nil {
+			return err
+		}
+		r.file = nil
+	}
+
+	backup := r.cfg.Path + "." + time.Now().Format(backupTimeFormat)
+	if err := os.Rename(r.cfg.Path, backup); err != nil && !os.IsNotExist(err) {
+		_ = r.open()
+		return err
+	}
+	if err := r.open(); err != nil {
+		return err
+	}
+
+	r.removeBackups()
+	return nil
+}
+
+// removeBackups removes the rotated files beyond MaxBackups or older than MaxAge.
+func (r *RotatingFile) removeBackups() {
+	if r.cfg.MaxBackups <= 0 && r.cfg.MaxAge <= 0 {
+		return
+	}
+
+	matches, err := filepath.Glob(r.cfg.Path + ".*")
+	if err != nil {
+		return
+	}
+
+	// Newest first: the timestamp suffix sorts chronologically.
+	slices.Sort(matches)
+	slices.Reverse(matches)
+
+	kept := 0
+	for _, m := range matches {
+		t, err := time.ParseInLocation(backupTimeFormat, m[len(r.cfg.Path)+1:], time.Local)
+		if err != nil {
+			continue // not a backup of this file
+		}
+
+		kept++
+		if (r.cfg.MaxBackups > 0 && kept > r.cfg.MaxBackups) || (r.cfg.MaxAge > 0 && time.Since(t) > r.cfg.MaxAge) {
+			_ = os.Remove(m)
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package logz
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// EnvLogSamplingInterval is the environment variable with the interval in which
+	// LOG_SAMPLING_FIRST and LOG_SAMPLING_THEREAFTER are counted, as a duration (defaults to "1s").
+	EnvLogSamplingInterval = "LOG_SAMPLING_INTERVAL"
+	// EnvLogSamplingFirst is the environment variable with the number of records with the same
+	// level and message logged per interval before sampling them (defaults to 0, no sampling).
+	EnvLogSamplingFirst = "LOG_SAMPLING_FIRST"
+	// EnvLogSamplingThereafter is the environment variable with the sampling rate after the first
+	// records: one of every LOG_SAMPLING_THEREAFTER is logged (defaults to 0, none).
+	EnvLogSamplingThereafter = "LOG_SAMPLING_THEREAFTER"
+	// EnvLogSamplingRates is the environment variable with the fraction of records kept per level,
+	// as level=fraction pairs separated by commas (e.g. "debug=0.1,info=0.5").
+	EnvLogSamplingRates = "LOG_SAMPLING_RATES"
+
+	// defaultSamplingInterval is the interval used when SamplingConfig.Interval is zero.
+	defaultSamplingInterval = time.Second
+)
+
+type (
+	// SamplingConfig configures a SamplingHandler. Both mechanisms can be combined: the
+	// probabilistic rate is applied first and the records it keeps are then counted per message.
+	SamplingConfig struct {
+		// Interval is the window in which First and Thereafter are counted. Defaults to one second.
+		Interval time.Duration
+		// First is the number of records with the same level and message logged per interval
+		// before sampling them. Zero disables sampling per message.
+		First int
+		// Thereafter logs one of every Thereafter records after the first ones in the interval.
+		// Zero suppresses all of them until the next interval.
+		Thereafter int
+		// Rates is the fraction of records kept per level, between 0 and 1 (e.g. 0.1 keeps one in
+		// ten debug records at random). Levels without a rate are always kept.
+		Rates map[slog.Level]float64
+	}
+
+	// SamplingHandler is a slog.Handler that suppresses part of the records of hot paths before
+	// passing the rest on, and counts the suppressed ones (see Suppressed).
+	SamplingHandler struct {
+		// next is the handler that writes the records.
+		next slog.Handler
+		// s is the sampling state, shared by the handlers derived with WithAttrs and WithGroup.
+		s *sampler
+	}
+
+	// sampler holds the counters of a SamplingHandler.
+	sampler struct {
+		// cfg is the sampling configuration.
+		cfg SamplingConfig
+		// now returns the current time.
+		now func() time.Time
+		// mu guards windowStart and counts.
+		mu sync.Mutex
+		// windowStart is when the current interval started.
+		windowStart time.Time
+		// counts are the records seen in the current interval per level and message.
+		counts map[samplingKey]int
+		// suppressed counts the records not passed on.
+		suppressed atomic.Uint64
+	}
+
+	// samplingKey identifies the records counted together.
+	samplingKey struct {
+		// level is the level of the records.
+		level slog.Level
+		// msg is the message of the records.
+		msg string
+	}
+)
+
+// WithSampling replaces the sampling read from the LOG_SAMPLING_* environment variables. A zero
+// SamplingConfig disables it.
+func WithSampling(cfg SamplingConfig) Option {
+	return func(o *options) {
+		o.sampling = cfg
+	}
+}
+
+// NewSamplingHandler wraps next so that, per level and message, only the first cfg.First
+// records of each interval and one of every cfg.Thereafter after them are logged, and only the
+// fraction cfg.Rates of each level.
+func NewSamplingHandler(next slog.Handler, cfg SamplingConfig) *SamplingHandler {
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaultSamplingInterval
+	}
+	return &SamplingHandler{
+		next: next,
+		s:    &sampler{cfg: cfg, now: time.Now, counts: make(map[samplingKey]int)},
+	}
+}
+
+// Suppressed returns the number of records the handler did not pass on.
+func (h *SamplingHandler) Suppressed() uint64 {
+	return h.s.suppressed.Load()
+}
+
+// Enabled implements slog.Handler.
+func (h *SamplingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle implements slog.Handler.
+func (h *SamplingHandler) Handle(ctx context.Context, r slog.Record) error {
+	if !h.s.sample(r.Level, r.Message) {
+		h.s.suppressed.Add(1)
+		return nil
+	}
+	return h.next.Handle(ctx, r)
+}
+
+// WithAttrs implements slog.Handler.
+func (h *SamplingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &SamplingHandler{next: h.next.WithAttrs(attrs), s: h.s}
+}
+
+// WithGroup implements slog.Handler.
+func (h *SamplingHandler) WithGroup(name string) slog.Handler {
+	return &SamplingHandler{next: h.next.WithGroup(name), s: h.s}
+}
+
+// enabled reports whether cfg samples any record.
+func (cfg SamplingConfig) enabled() bool {
+	return cfg.First > 0 || len(cfg.Rates) > 0
+}
+
+// sample reports whether a record with the level and message must be logged.
+func (s *sampler) sample(level slog.Level, msg string) bool {
+	if rate, ok := s.cfg.Rates[level]; ok && rate < 1 && rand.Float64() >= rate {
+		return false
+	}
+	if s.cfg.First <= 0 {
+		return true
+	}
+
+	s.mu.Lock()
+	if now := s.now(); now.Sub(s.windowStart) >= s.cfg.Interval {
+		clear(s.counts)
+		s.windowStart = now
+	}
+	key := samplingKey{level: level, msg: msg}
+	s.counts[key]++
+	n := s.counts[key]
+	s.mu.Unlock()
+
+	if n <= s.cfg.First {
+		return true
+	}
+	return s.cfg.Thereafter > 0 && (n-s.cfg.First)%s.cfg.Thereafter == 0
+}
+
+// samplingFromEnv returns the sampling configured by the LOG_SAMPLING_* environment variables.
+func samplingFromEnv() (SamplingConfig, error) {
+	var cfg SamplingConfig
+
+	if v := os.Getenv(EnvLogSamplingInterval); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return cfg, fmt.Errorf("logz: valor inválido en %s: %w", EnvLogSamplingInterval, err)
+		}
+		cfg.Interval = d
+	}
+	if v := os.Getenv(EnvLogSamplingFirst); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return cfg, fmt.Errorf("logz: valor inválido en %s: %w", EnvLogSamplingFirst, err)
+		}
+		cfg.First = n
+	}
+	if v := os.Getenv(EnvLogSamplingThereafter); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return cfg, fmt.Errorf("logz: valor inválido en %s: %w", EnvLogSamplingThereafter, err)
+		}
+		cfg.Thereafter = n
+	}
+	if v := os.Getenv(EnvLogSamplingRates); v != "" {
+		cfg.Rates = make(map[slog.Level]float64)
+		for _, pair := range strings.Split(v, ",") {
+			name, value, ok := strings.Cut(pair, "=")
+			if !ok {
+				return cfg, fmt.Errorf("logz: valor inválido en %s: %q", EnvLogSamplingRates, pair)
+			}
+			l, err := ParseLevel(name)
+			if err != nil {
+				return cfg, err
+			}
+			rate, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil || rate < 0 || rate > 1 {
+				return cfg, fmt.Errorf("logz: valor inválido en %s: %q", EnvLogSamplingRates, pair)
+			}
+			cfg.Rates[l] = rate
+		}
+	}
+	return cfg, nil
+}
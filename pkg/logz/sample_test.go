@@ -0,0 +1,120 @@
+package logz
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSamplingHandler(t *testing.T) {
+	t.Run("first then every M per message", func(t *testing.T) {
+		var buf bytes.Buffer
+		h := NewSamplingHandler(slog.NewTextHandler(&buf, nil), SamplingConfig{Interval: time.Hour, First: 3, Thereafter: 5})
+		logger := slog.New(h)
+
+		for i := 0; i < 23; i++ {
+			logger.Info("hot")
+		}
+		logger.Info("cold")
+
+		// 3 first + the 5th, 10th, 15th and 20th of the remaining 20
+		if got := strings.Count(buf.String(), "msg=hot"); got != 7 {
+			t.Errorf("expected 7 hot records, got %d", got)
+		}
+		if !strings.Contains(buf.String(), "msg=cold") {
+			t.Error("expected other messages to be counted separately")
+		}
+		if h.Suppressed() != 16 {
+			t.Errorf("expected 16 suppressed records, got %d", h.Suppressed())
+		}
+	})
+
+	t.Run("counters reset every interval", func(t *testing.T) {
+		var buf bytes.Buffer
+		h := NewSamplingHandler(slog.NewTextHandler(&buf, nil), SamplingConfig{Interval: time.Second, First: 1})
+		now := time.Now()
+		h.s.now = func() time.Time { return now }
+		logger := slog.New(h).With("k", "v")
+
+		logger.Warn("hot")
+		logger.Warn("hot")
+		now = now.Add(time.Second)
+		logger.Warn("hot")
+
+		if got := strings.Count(buf.String(), "msg=hot"); got != 2 {
+			t.Errorf("expected 2 records, got %d", got)
+		}
+		if h.Suppressed() != 1 {
+			t.Errorf("expected 1 suppressed record, got %d", h.Suppressed())
+		}
+	})
+
+	t.Run("rates per level", func(t *testing.T) {
+		var buf bytes.Buffer
+		h := NewSamplingHandler(
+			slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}),
+			SamplingConfig{Rates: map[slog.Level]float64{slog.LevelDebug: 0, slog.LevelInfo: 1}},
+		)
+		logger := slog.New(h)
+
+		for i := 0; i < 10; i++ {
+			logger.Debug("debug")
+			logger.Info("info")
+			logger.Error("error")
+		}
+
+		out := buf.String()
+		if strings.Contains(out, "msg=debug") {
+			t.Error("expected debug records to be suppressed")
+		}
+		if strings.Count(out, "msg=info") != 10 || strings.Count(out, "msg=error") != 10 {
+			t.Errorf("expected info and error records to be kept, got %s", out)
+		}
+		if h.Suppressed() != 10 {
+			t.Errorf("expected 10 suppressed records, got %d", h.Suppressed())
+		}
+	})
+}
+
+func TestSamplingFromEnv(t *testing.T) {
+	t.Setenv(EnvLogSamplingInterval, "2s")
+	t.Setenv(EnvLogSamplingFirst, "100")
+	t.Setenv(EnvLogSamplingThereafter, "10")
+	t.Setenv(EnvLogSamplingRates, "debug=0.1, info=0.5")
+
+	cfg, err := samplingFromEnv()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Interval != 2*time.Second || cfg.First != 100 || cfg.Thereafter != 10 {
+		t.Errorf("unexpected config: %+v", cfg)
+	}
+	if cfg.Rates[slog.LevelDebug] != 0.1 || cfg.Rates[slog.LevelInfo] != 0.5 {
+		t.Errorf("unexpected rates: %v", cfg.Rates)
+	}
+
+	t.Setenv(EnvLogSamplingRates, "debug=2")
+	if _, err := samplingFromEnv(); err == nil {
+		t.Error("expected an error for a rate above 1")
+	}
+}
+
+func TestBuildHandler_Sampling(t *testing.T) {
+	var buf bytes.Buffer
+	o := &options{sinks: []Sink{{Writer: &buf}}, sampling: SamplingConfig{Interval: time.Hour, First: 1}}
+	logger := slog.New(buildHandler(o))
+	t.Cleanup(func() {
+		outputsMu.Lock()
+		samplingHandler = nil
+		outputsMu.Unlock()
+	})
+
+	logger.Info("hot")
+	logger.Info("hot")
+
+	if Suppressed() != 1 {
+		t.Errorf("expected 1 suppressed record, got %d", Suppressed())
+	}
+}
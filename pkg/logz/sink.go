@@ -0,0 +1,361 @@
+package logz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// EnvLogOutput is the environment variable listing the sinks of the global logger, separated by
+	// commas: "stdout" (default), "stderr" and "file" (e.g. "stdout,file").
+	EnvLogOutput = "LOG_OUTPUT"
+	// EnvLogFilePath is the environment variable with the path of the "file" sink (defaults to "app.log").
+	EnvLogFilePath = "LOG_FILE_PATH"
+	// EnvLogFileLevel is the environment variable with the minimum level of the "file" sink
+	// (defaults to LOG_LEVEL).
+	EnvLogFileLevel = "LOG_FILE_LEVEL"
+	// EnvLogFileMaxSize is the environment variable with the size in megabytes that rotates the
+	// "file" sink (defaults to 100; 0 disables rotation).
+	EnvLogFileMaxSize = "LOG_FILE_MAX_SIZE_MB"
+	// EnvLogFileMaxAge is the environment variable with how long rotated files are kept, as a
+	// duration (e.g. "168h"; defaults to keeping them).
+	EnvLogFileMaxAge = "LOG_FILE_MAX_AGE"
+	// EnvLogFileMaxBackups is the environment variable with the number of rotated files kept
+	// (defaults to keeping all of them).
+	EnvLogFileMaxBackups = "LOG_FILE_MAX_BACKUPS"
+	// EnvLogAsyncBuffer is the environment variable with the number of entries buffered by the
+	// asynchronous writer of each sink (defaults to 0, synchronous writes).
+	EnvLogAsyncBuffer = "LOG_ASYNC_BUFFER"
+)
+
+type (
+	// Sink is a destination of the log entries.
+	Sink struct {
+		// Writer receives the formatted entries.
+		Writer io.Writer
+		// Level is the minimum level of the sink. If nil, the level of the global logger is used
+		// (LOG_LEVEL, SetLevel).
+		Level slog.Leveler
+		// JSON selects the JSON format instead of text.
+		JSON bool
+	}
+
+	// Option configures the global logger initialized with MustInitWith.
+	Option func(*options)
+
+	// options holds the settings of the global logger.
+	options struct {
+		// sinks are the destinations of the entries.
+		sinks []Sink
+		// asyncBuffer is the buffer size of the asynchronous writers, or 0 for synchronous writes.
+		asyncBuffer int
+		// staticArgs are added to every entry.
+		staticArgs []any
+		// redactor hides the sensitive data of the entries, or nil to write them as they are.
+		redactor *Redactor
+		// sampling suppresses part of the entries of hot paths.
+		sampling SamplingConfig
+	}
+
+	// multiHandler is a slog.Handler that sends every record to the handlers whose level
+	// enables it.
+	multiHandler struct {
+		// handlers are the handlers of the sinks.
+		handlers []slog.Handler
+	}
+)
+
+var (
+	// outputsMu guards asyncWriters.
+	outputsMu sync.Mutex
+	// asyncWriters are the asynchronous writers of the global logger, flushed by Flush.
+	asyncWriters []*AsyncWriter
+	// samplingHandler is the sampling handler of the global logger, if sampling is enabled.
+	samplingHandler *SamplingHandler
+)
+
+// WithSinks replaces the sinks read from the environment. Each entry is sent to every sink
+// whose level enables it.
+func WithSinks(sinks ...Sink) Option {
+	return func(o *options) {
+		o.sinks = sinks
+	}
+}
+
+// WithAsync buffers up to bufferSize entries per sink and writes them in the background (see
+// AsyncWriter). Zero disables it. Call Flush before the application exits; launcher does it
+// on shutdown.
+func WithAsync(bufferSize int) Option {
+	return func(o *options) {
+		o.asyncBuffer = bufferSize
+	}
+}
+
+// WithStaticArgs adds fields to every entry (e.g., service name, environment).
+func WithStaticArgs(args ...any) Option {
+	return func(o *options) {
+		o.staticArgs = append(o.staticArgs, args...)
+	}
+}
+
+// WithRedactor replaces DefaultRedactor as the redactor of the entries. A nil redactor disables
+// redaction.
+func WithRedactor(r *Redactor) Option {
+	return func(o *options) {
+		o.redactor = r
+	}
+}
+
+// NewHandler returns a slog.Handler that writes to every sink, adding the fields stored in
+// the context of each record (see NewContextHandler). It does not redact the entries; wrap it
+// with NewRedactHandler, or use the global logger, which does.
+func NewHandler(sinks ...Sink) slog.Handler {
+	return NewContextHandler(sinksHandler(sinks, level))
+}
+
+// Flush waits until the entries buffered by the asynchronous writers of the global logger have
+// been written. It is a launcher.Hook, and launcher calls it on shutdown.
+func Flush() error {
+	outputsMu.Lock()
+	writers := asyncWriters
+	outputsMu.Unlock()
+
+	var errs []error
+	for _, w := range writers {
+		errs = append(errs, w.Flush())
+	}
+	return errors.Join(errs...)
+}
+
+// Dropped returns the number of entries the asynchronous writers of the global logger
+// discarded because their buffer was full.
+func Dropped() uint64 {
+	outputsMu.Lock()
+	defer outputsMu.Unlock()
+
+	var n uint64
+	for _, w := range asyncWriters {
+		n += w.Dropped()
+	}
+	return n
+}
+
+// Suppressed returns the number of entries the global logger did not write because of sampling
+// (LOG_SAMPLING_*, WithSampling).
+func Suppressed() uint64 {
+	outputsMu.Lock()
+	defer outputsMu.Unlock()
+
+	if samplingHandler == nil {
+		return 0
+	}
+	return samplingHandler.Suppressed()
+}
+
+// Enabled implements slog.Handler.
+func (h *multiHandler) Enabled(ctx context.Context, l slog.Level) bool {
+	for _, handler := range h.handlers {
+		if handler.Enabled(ctx, l) {
+			return true
+		}
+	}
+	return false
+}
+
+// Handle implements slog.Handler.
+func (h *multiHandler) Handle(ctx context.Context, r slog.Record) error {
+	var errs []error
+	for _, handler := range h.handlers {
+		if handler.Enabled(ctx, r.Level) {
+			errs = append(errs, handler.Handle(ctx, r.Clone()))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// WithAttrs implements slog.Handler.
+func (h *multiHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handlers := make([]slog.Handler, len(h.handlers))
+	for i, handler := range h.handlers {
+		handlers[i] = handler.WithAttrs(attrs)
+	}
+	return &multiHandler{handlers: handlers}
+}
+
+// WithGroup implements slog.Handler.
+func (h *multiHandler) WithGroup(name string) slog.Handler {
+	handlers := make([]slog.Handler, len(h.handlers))
+	for i, handler := range h.handlers {
+		handlers[i] = handler.WithGroup(name)
+	}
+	return &multiHandler{handlers: handlers}
+}
+
+// sinksFromEnv returns the sinks listed in LOG_OUTPUT.
+func sinksFromEnv() ([]Sink, error) {
+	isJSON := getLogFormatFromEnv()
+
+	var sinks []Sink
+	for _, name := range strings.Split(os.Getenv(EnvLogOutput), ",") {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "", "stdout":
+			sinks = append(sinks, Sink{Writer: os.Stdout, JSON: isJSON})
+		case "stderr":
+			sinks = append(sinks, Sink{Writer: os.Stderr, JSON: isJSON})
+		case "file":
+			sink, err := fileSinkFromEnv(isJSON)
+			if err != nil {
+				return nil, err
+			}
+			sinks = append(sinks, sink)
+		default:
+			return nil, fmt.Errorf("logz: salida desconocida en %s: %q", EnvLogOutput, name)
+		}
+	}
+	return sinks, nil
+}
+
+// asyncBufferFromEnv returns the buffer size set in LOG_ASYNC_BUFFER, or 0.
+func asyncBufferFromEnv() (int, error) {
+	v := os.Getenv(EnvLogAsyncBuffer)
+	if v == "" {
+		return 0, nil
+	}
+
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("logz: valor inválido en %s: %w", EnvLogAsyncBuffer, err)
+	}
+	return n, nil
+}
+
+// fileSinkFromEnv returns the "file" sink configured by the LOG_FILE_* environment variables.
+func fileSinkFromEnv(isJSON bool) (Sink, error) {
+	cfg := FileConfig{Path: os.Getenv(EnvLogFilePath), MaxSize: 100 << 20}
+	if cfg.Path == "" {
+		cfg.Path = "app.log"
+	}
+
+	if v := os.Getenv(EnvLogFileMaxSize); v != "" {
+		mb, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return Sink{}, fmt.Errorf("logz: valor inválido en %s: %w", EnvLogFileMaxSize, err)
+		}
+		cfg.MaxSize = mb << 20
+	}
+	if v := os.Getenv(EnvLogFileMaxAge); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return Sink{}, fmt.Errorf("logz: valor inválido en %s: %w", EnvLogFileMaxAge, err)
+		}
+		cfg.MaxAge = d
+	}
+	if v := os.Getenv(EnvLogFileMaxBackups); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return Sink{}, fmt.Errorf("logz: valor inválido en %s: %w", EnvLogFileMaxBackups, err)
+		}
+		cfg.MaxBackups = n
+	}
+
+	sink := Sink{JSON: isJSON}
+	if v := os.Getenv(EnvLogFileLevel); v != "" {
+		l, err := ParseLevel(v)
+		if err != nil {
+			return Sink{}, err
+		}
+		sink.Level = l
+	}
+
+	file, err := NewRotatingFile(cfg)
+	if err != nil {
+		return Sink{}, fmt.Errorf("logz: no se pudo abrir el archivo de log: %w", err)
+	}
+	sink.Writer = file
+	return sink, nil
+}
+
+// sinksHandler returns the handler writing to the sinks, each with its own level or defaultLevel.
+// A nil defaultLevel means the level of the logger: those sinks skip the records marked by
+// levelHandler as below it.
+func sinksHandler(sinks []Sink, defaultLevel slog.Leveler) slog.Handler {
+	handlers := make([]slog.Handler, 0, len(sinks))
+	for _, s := range sinks {
+		opts := &slog.HandlerOptions{Level: s.Level}
+		if opts.Level == nil {
+			opts.Level = defaultLevel
+		}
+		if opts.Level == nil {
+			opts.Level = allLevels
+		}
+
+		var h slog.Handler
+		if s.JSON {
+			h = slog.NewJSONHandler(s.Writer, opts)
+		} else {
+			h = slog.NewTextHandler(s.Writer, opts)
+		}
+		if s.Level == nil && defaultLevel == nil {
+			h = &loggerLevelSink{next: h}
+		}
+		handlers = append(handlers, h)
+	}
+
+	if len(handlers) == 1 {
+		return handlers[0]
+	}
+	return &multiHandler{handlers: handlers}
+}
+
+// buildHandler returns the handler of the global logger for o. With an async buffer, the writer
+// of every sink is wrapped in an AsyncWriter registered for Flush. The sampler and the redactor
+// run after the context handler, so the fields stored in the context are redacted too, and the
+// sampler runs first so suppressed records are not redacted. The level of the global logger is
+// checked by the outermost handler, which Named replaces with the level of each named logger;
+// the sinks with a level of their own still get the records they enable below it.
+func buildHandler(o *options) slog.Handler {
+	sinks := slices.Clone(o.sinks)
+
+	if o.asyncBuffer > 0 {
+		outputsMu.Lock()
+		for i := range sinks {
+			w := NewAsyncWriter(sinks[i].Writer, o.asyncBuffer)
+			asyncWriters = append(asyncWriters, w)
+			sinks[i].Writer = w
+		}
+		outputsMu.Unlock()
+	}
+
+	var floor minLevel
+	for _, s := range sinks {
+		if s.Level != nil {
+			floor = append(floor, s.Level)
+		}
+	}
+
+	handler := sinksHandler(sinks, nil)
+	if o.redactor != nil {
+		handler = NewRedactHandler(handler, o.redactor)
+	}
+	if o.sampling.enabled() {
+		sh := NewSamplingHandler(handler, o.sampling)
+		outputsMu.Lock()
+		samplingHandler = sh
+		outputsMu.Unlock()
+		handler = sh
+	}
+	root := &levelHandler{next: NewContextHandler(handler), level: rootLevel}
+	if len(floor) > 0 {
+		root.floor = floor
+	}
+	return root
+}
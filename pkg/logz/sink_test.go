@@ -0,0 +1,215 @@
+package logz
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// blockingWriter blocks every write until release is closed.
+type blockingWriter struct {
+	release chan struct{}
+	mu      sync.Mutex
+	buf     bytes.Buffer
+}
+
+func (w *blockingWriter) Write(p []byte) (int, error) {
+	<-w.release
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.Write(p)
+}
+
+func (w *blockingWriter) String() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.String()
+}
+
+func TestAsyncWriter(t *testing.T) {
+	t.Run("flush writes the pending entries", func(t *testing.T) {
+		w := &blockingWriter{release: make(chan struct{})}
+		close(w.release)
+		a := NewAsyncWriter(w, 10)
+
+		for i := 0; i < 5; i++ {
+			_, _ = a.Write([]byte("entry\n"))
+		}
+		if err := a.Flush(); err != nil {
+			t.Fatalf("Flush failed: %v", err)
+		}
+		if got := strings.Count(w.String(), "entry"); got != 5 {
+			t.Errorf("expected 5 entries after Flush, got %d", got)
+		}
+	})
+
+	t.Run("drops on overflow without blocking", func(t *testing.T) {
+		w := &blockingWriter{release: make(chan struct{})}
+		a := NewAsyncWriter(w, 2)
+
+		done := make(chan struct{})
+		go func() {
+			for i := 0; i < 10; i++ {
+				_, _ = a.Write([]byte("entry\n"))
+			}
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("expected Write not to block on a slow writer")
+		}
+		if a.Dropped() < 7 {
+			t.Errorf("expected at least 7 dropped entries, got %d", a.Dropped())
+		}
+
+		close(w.release)
+		if err := a.Close(); err != nil {
+			t.Fatalf("Close failed: %v", err)
+		}
+		written := strings.Count(w.String(), "entry")
+		if uint64(written)+a.Dropped() != 10 {
+			t.Errorf("expected written (%d) + dropped (%d) = 10", written, a.Dropped())
+		}
+
+		_, _ = a.Write([]byte("late\n"))
+		if !strings.Contains(w.String(), "late") {
+			t.Error("expected writes after Close to be synchronous")
+		}
+	})
+}
+
+func TestRotatingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "app.log")
+	f, err := NewRotatingFile(FileConfig{Path: path, MaxSize: 20, MaxBackups: 2})
+	if err != nil {
+		t.Fatalf("NewRotatingFile failed: %v", err)
+	}
+	defer f.Close()
+
+	for i := 0; i < 4; i++ {
+		if _, err := f.Write([]byte("0123456789abcdef\n")); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+		time.Sleep(2 * time.Millisecond) // distinct backup timestamps
+	}
+
+	backups, _ := filepath.Glob(path + ".*")
+	if len(backups) != 2 {
+		t.Errorf("expected 2 backups, got %v", backups)
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != "0123456789abcdef\n" {
+		t.Errorf("expected only the last entry in the current file, got %q", data)
+	}
+
+	if err := f.Rotate(); err != nil {
+		t.Fatalf("Rotate failed: %v", err)
+	}
+	if info, _ := os.Stat(path); info.Size() != 0 {
+		t.Errorf("expected an empty file after Rotate, got %d bytes", info.Size())
+	}
+}
+
+func TestRotatingFile_RecoversFromFailedRotation(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "logs")
+	path := filepath.Join(dir, "app.log")
+	f, err := NewRotatingFile(FileConfig{Path: path})
+	if err != nil {
+		t.Fatalf("NewRotatingFile failed: %v", err)
+	}
+	defer f.Close()
+
+	// A file in place of the directory makes the rotation fail
+	if err := os.RemoveAll(dir); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(dir, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Rotate(); err == nil {
+		t.Fatal("expected Rotate to fail")
+	}
+
+	if err := os.Remove(dir); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Write([]byte("entry\n")); err != nil {
+		t.Fatalf("expected Write to reopen the file, got %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "entry\n" {
+		t.Errorf("expected the entry in the reopened file, got %q", data)
+	}
+
+	_ = f.Close()
+	if _, err := f.Write([]byte("late\n")); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("expected os.ErrClosed after Close, got %v", err)
+	}
+}
+
+func TestNewHandler_PerSinkLevel(t *testing.T) {
+	var all, errorsOnly bytes.Buffer
+	logger := slog.New(NewHandler(
+		Sink{Writer: &all, Level: slog.LevelDebug, JSON: true},
+		Sink{Writer: &errorsOnly, Level: slog.LevelError},
+	)).With("service", "test")
+
+	logger.Debug("debug entry")
+	logger.Error("error entry")
+
+	if !strings.Contains(all.String(), "debug entry") || !strings.Contains(all.String(), "error entry") {
+		t.Errorf("expected both entries in the debug sink, got %s", all.String())
+	}
+	if strings.Contains(errorsOnly.String(), "debug entry") || !strings.Contains(errorsOnly.String(), "service=test") {
+		t.Errorf("expected only the error entry in the error sink, got %s", errorsOnly.String())
+	}
+}
+
+func TestSinksFromEnv(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	t.Setenv(EnvLogOutput, "stderr, file")
+	t.Setenv(EnvLogFilePath, path)
+	t.Setenv(EnvLogFileLevel, "warn")
+
+	sinks, err := sinksFromEnv()
+	if err != nil {
+		t.Fatalf("sinksFromEnv failed: %v", err)
+	}
+	if len(sinks) != 2 || sinks[0].Writer != os.Stderr || sinks[1].Level != slog.LevelWarn {
+		t.Fatalf("unexpected sinks: %+v", sinks)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("expected the log file to be created: %v", err)
+	}
+	_ = sinks[1].Writer.(*RotatingFile).Close()
+
+	t.Setenv(EnvLogOutput, "syslog")
+	if _, err := sinksFromEnv(); err == nil {
+		t.Error("expected an error for an unknown output")
+	}
+}
+
+func TestFlush(t *testing.T) {
+	var buf bytes.Buffer
+	handler := buildHandler(&options{sinks: []Sink{{Writer: &buf}}, asyncBuffer: 10})
+	t.Cleanup(func() {
+		outputsMu.Lock()
+		asyncWriters = nil
+		outputsMu.Unlock()
+	})
+
+	slog.New(handler).Info("buffered entry")
+	if err := Flush(); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "buffered entry") || Dropped() != 0 {
+		t.Errorf("expected the entry after Flush, got %q (dropped %d)", buf.String(), Dropped())
+	}
+}
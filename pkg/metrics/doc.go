@@ -0,0 +1,36 @@
+/*
+Package metrics counts the errors of the application by code, so spikes of INTERNAL_ERROR can be
+told apart from client errors without parsing the logs.
+
+The counters are sent to a pluggable ErrorCounter set with SetErrorCounter; without one they are
+disabled. Each error is counted with its ErrorLabels:
+  - Code: the apperr.ErrorCode.
+  - Class: ClassServer for codes mapped to HTTP 5xx, ClassClient otherwise.
+  - Route and Method: the route template and method of the request, for HTTP errors.
+  - Source: SourceHTTP for the responses of mw.AppErrorHandler, SourceLog for logz.LogError.
+
+Errors of HTTP requests are both answered and logged, so they are counted under both sources;
+dashboards should filter by one of them. The exception is NOT_FOUND, which mw.AppErrorHandler
+logs at WARN level instead of with LogError, so it is only counted under SourceHTTP.
+
+Memory is an in-process ErrorCounter whose Report gives the error budget consumed per class and
+code, e.g. for tests or an internal endpoint.
+
+Example usage:
+
+	// Adapter to a Prometheus CounterVec
+	type promCounter struct{ vec *prometheus.CounterVec }
+
+	func (p promCounter) IncError(l metrics.ErrorLabels) {
+		p.vec.WithLabelValues(l.Code, l.Class, l.Route, l.Method, l.Source).Inc()
+	}
+
+	metrics.SetErrorCounter(promCounter{vec: errorsTotal})
+
+	// In-process counts
+	mem := metrics.NewMemory()
+	metrics.SetErrorCounter(mem)
+	report := mem.Report(metrics.SourceHTTP)
+	fmt.Println(report.Server, report.Client, report.ServerRatio())
+*/
+package metrics
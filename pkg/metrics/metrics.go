@@ -0,0 +1,169 @@
+package metrics
+
+import (
+	"maps"
+	"net/http"
+	"sync"
+
+	"github.com/nochebuenadev/go-kit/pkg/apperr"
+)
+
+type (
+	// ErrorLabels are the labels of an error counter.
+	ErrorLabels struct {
+		// Code is the apperr.ErrorCode of the error (e.g. "INTERNAL_ERROR").
+		Code string
+		// Class is ClassServer for codes mapped to HTTP 5xx and ClassClient otherwise.
+		Class string
+		// Route is the route template of the request (e.g. "/users/:id"), empty outside HTTP.
+		Route string
+		// Method is the HTTP method of the request, empty outside HTTP.
+		Method string
+		// Source is where the error was counted: SourceHTTP or SourceLog.
+		Source string
+	}
+
+	// ErrorCounter is the pluggable backend of the error metrics, e.g. an adapter to a
+	// Prometheus CounterVec or an OpenTelemetry Int64Counter.
+	ErrorCounter interface {
+		// IncError increments the counter of the errors with the given labels.
+		IncError(labels ErrorLabels)
+	}
+
+	// Memory is an in-process ErrorCounter, useful in tests and to report the error budget
+	// without an external metrics system.
+	Memory struct {
+		// mu guards counts.
+		mu sync.Mutex
+		// counts holds the number of errors per set of labels.
+		counts map[ErrorLabels]uint64
+	}
+
+	// Report summarizes the errors counted by a Memory counter.
+	Report struct {
+		// Total is the number of errors.
+		Total uint64 `json:"total"`
+		// Client is the number of errors of ClassClient.
+		Client uint64 `json:"client"`
+		// Server is the number of errors of ClassServer.
+		Server uint64 `json:"server"`
+		// ByCode is the number of errors per code.
+		ByCode map[string]uint64 `json:"by_code"`
+	}
+)
+
+const (
+	// SourceHTTP counts the error responses sent by mw.AppErrorHandler.
+	SourceHTTP = "http"
+	// SourceLog counts the errors logged with logz.LogError. mw.AppErrorHandler logs the errors
+	// of HTTP requests with LogError too, so they are counted under both sources, except
+	// NOT_FOUND, which it logs at WARN level and is only counted under SourceHTTP.
+	SourceLog = "log"
+
+	// ClassClient is the class of the errors caused by the client (HTTP 4xx).
+	ClassClient = "client"
+	// ClassServer is the class of the errors caused by the server (HTTP 5xx).
+	ClassServer = "server"
+)
+
+var (
+	// counterMu guards counter.
+	counterMu sync.RWMutex
+	// counter is the ErrorCounter set with SetErrorCounter, if any.
+	counter ErrorCounter
+)
+
+// SetErrorCounter sets the backend that receives the error metrics. A nil counter disables them.
+func SetErrorCounter(c ErrorCounter) {
+	counterMu.Lock()
+	defer counterMu.Unlock()
+	counter = c
+}
+
+// IncError increments the error counter of the given labels. An empty Code counts as
+// apperr.ErrInternal, and an empty Class is filled from Code. It does nothing if no
+// ErrorCounter was set.
+func IncError(labels ErrorLabels) {
+	counterMu.RLock()
+	c := counter
+	counterMu.RUnlock()
+
+	if c == nil {
+		return
+	}
+	if labels.Code == "" {
+		labels.Code = string(apperr.ErrInternal)
+	}
+	if labels.Class == "" {
+		labels.Class = ClassOf(labels.Code)
+	}
+	c.IncError(labels)
+}
+
+// ClassOf returns the class of an error code: ClassServer if the code maps to an HTTP 5xx
+// status, ClassClient otherwise.
+func ClassOf(code string) string {
+	if apperr.ErrorCode(code).HTTPStatus() >= http.StatusInternalServerError {
+		return ClassServer
+	}
+	return ClassClient
+}
+
+// NewMemory creates an empty in-process ErrorCounter.
+func NewMemory() *Memory {
+	return &Memory{counts: make(map[ErrorLabels]uint64)}
+}
+
+// IncError implements ErrorCounter.
+func (m *Memory) IncError(labels ErrorLabels) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.counts[labels]++
+}
+
+// Count returns the number of errors counted with exactly the given labels.
+func (m *Memory) Count(labels ErrorLabels) uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.counts[labels]
+}
+
+// Snapshot returns a copy of the counts per set of labels.
+func (m *Memory) Snapshot() map[ErrorLabels]uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return maps.Clone(m.counts)
+}
+
+// Report summarizes the errors counted from source (SourceHTTP or SourceLog) by class and code.
+func (m *Memory) Report(source string) Report {
+	r := Report{ByCode: make(map[string]uint64)}
+	for labels, n := range m.Snapshot() {
+		if labels.Source != source {
+			continue
+		}
+		r.Total += n
+		r.ByCode[labels.Code] += n
+		if labels.Class == ClassServer {
+			r.Server += n
+		} else {
+			r.Client += n
+		}
+	}
+	return r
+}
+
+// Reset clears the counts.
+func (m *Memory) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	clear(m.counts)
+}
+
+// ServerRatio returns the share of server errors among all the errors, or zero without errors.
+func (r Report) ServerRatio() float64 {
+	if r.Total == 0 {
+		return 0
+	}
+	return float64(r.Server) / float64(r.Total)
+}
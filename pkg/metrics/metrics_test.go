@@ -0,0 +1,57 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/nochebuenadev/go-kit/pkg/apperr"
+)
+
+func TestIncError(t *testing.T) {
+	IncError(ErrorLabels{Code: "INTERNAL_ERROR"}) // no counter set: no-op
+
+	mem := NewMemory()
+	SetErrorCounter(mem)
+	t.Cleanup(func() { SetErrorCounter(nil) })
+
+	IncError(ErrorLabels{Code: string(apperr.ErrInternal), Route: "/users/:id", Method: "GET", Source: SourceHTTP})
+	IncError(ErrorLabels{Code: string(apperr.ErrInternal), Route: "/users/:id", Method: "GET", Source: SourceHTTP})
+	IncError(ErrorLabels{Code: string(apperr.ErrResourceNotFound), Route: "/users/:id", Method: "GET", Source: SourceHTTP})
+	IncError(ErrorLabels{Source: SourceLog})
+
+	internal := ErrorLabels{Code: "INTERNAL_ERROR", Class: ClassServer, Route: "/users/:id", Method: "GET", Source: SourceHTTP}
+	if n := mem.Count(internal); n != 2 {
+		t.Errorf("expected 2 internal errors, got %d", n)
+	}
+	if n := mem.Count(ErrorLabels{Code: "INTERNAL_ERROR", Class: ClassServer, Source: SourceLog}); n != 1 {
+		t.Errorf("expected an empty code to count as INTERNAL_ERROR, got %d", n)
+	}
+
+	report := mem.Report(SourceHTTP)
+	if report.Total != 3 || report.Server != 2 || report.Client != 1 || report.ByCode["NOT_FOUND"] != 1 {
+		t.Errorf("unexpected report: %+v", report)
+	}
+	if ratio := report.ServerRatio(); ratio < 0.66 || ratio > 0.67 {
+		t.Errorf("unexpected server ratio: %f", ratio)
+	}
+
+	mem.Reset()
+	if len(mem.Snapshot()) != 0 || mem.Report(SourceHTTP).ServerRatio() != 0 {
+		t.Error("expected no counts after Reset")
+	}
+}
+
+func TestClassOf(t *testing.T) {
+	tests := map[string]string{
+		"INTERNAL_ERROR":      ClassServer,
+		"SERVICE_UNAVAILABLE": ClassServer,
+		"INVALID_ARGUMENT":    ClassClient,
+		"NOT_FOUND":           ClassClient,
+		"NOT_REGISTERED":      ClassServer,
+	}
+
+	for code, expected := range tests {
+		if got := ClassOf(code); got != expected {
+			t.Errorf("%s: expected %s, got %s", code, expected, got)
+		}
+	}
+}
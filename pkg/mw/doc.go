@@ -7,11 +7,23 @@ services within the go-kit ecosystem behave consistently.
 
 Middlewares:
   - AppErrorHandler: Standardizes error responses by mapping apperr.AppErr and echo.HTTPError
-    to consistent HTTP formats and status codes.
+    to consistent HTTP formats and status codes. With WithProblemDetails, clients that send
+    "Accept: application/problem+json" receive RFC 9457 problem details (type, title, status,
+    detail, instance set to the request ID, and the error code and context as extensions).
+    Field violations (apperr.FieldViolation) are rendered as an "errors" array in both formats.
+    Only the public context is sent; internal diagnostics (WithInternal) are logged only.
+    Errors with a retry-after duration (WithRetryAfter) set the Retry-After header.
+    Each response is counted in the error metrics (package metrics) by code, route and method.
   - WithRequestID: propagates correlation IDs from headers to the context for tracing.
+  - WithLocale: negotiates the locale from Accept-Language and stores it in the context (i18n).
+    AppErrorHandler translates field violations to that locale, and error messages the
+    catalogue knows (i18n.MessageKey, or the key set with apperr.AppErr.WithMessageKey);
+    specific messages are kept as written.
   - FirebaseAuth: validates identity tokens using the Firebase Admin SDK.
   - EnrichmentMiddleware: extracts tenant IDs and metadata from JWT claims.
   - Authorizer (RBAC): enforces permission-based access control at the route level.
+    GetAuthorizer returns a shared instance; NewAuthorizer builds independent ones.
+    With WithAppIDLoader, the application ID is read again on OnReload (launcher.Reloadable).
 
 Each middleware is designed to be easily pluggable and adheres to the project's
 structured logging (logz) and error reporting (apperr) standards.
@@ -6,6 +6,7 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/nochebuenadev/go-kit/pkg/apperr"
 	"github.com/nochebuenadev/go-kit/pkg/authz"
+	"github.com/nochebuenadev/go-kit/pkg/i18n"
 	"github.com/nochebuenadev/go-kit/pkg/logz"
 )
 
@@ -30,7 +31,10 @@ func EnrichmentMiddleware(isEnabled bool, tenantHeader string, optionalHeaders m
 
 			tenantID := c.Request().Header.Get(tenantHeader)
 			if tenantID == "" {
-				return apperr.InvalidInput("el encabezado %q es requerido para identificar el tenant", tenantHeader).
+				key := i18n.MessageKey(`el encabezado "{header}" es requerido para identificar el tenant`)
+				params := map[string]string{"header": tenantHeader}
+				return apperr.New(apperr.ErrInvalidInput, i18n.Default().Translate(i18n.DefaultLocale, key, params)).
+					WithMessageKey(key, params).
 					WithContext("header", tenantHeader)
 			}
 
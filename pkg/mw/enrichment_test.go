@@ -2,6 +2,7 @@ package mw
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -27,6 +28,34 @@ func TestEnrichmentMiddleware(t *testing.T) {
 		}
 	})
 
+	t.Run("missing tenant ID is localized", func(t *testing.T) {
+		mw := EnrichmentMiddleware(true, "X-Tenant-ID", nil)
+		handler := mw(func(c echo.Context) error { return nil })
+
+		tests := map[string]string{
+			"es": `el encabezado "X-Tenant-ID" es requerido para identificar el tenant`,
+			"en": `the "X-Tenant-ID" header is required to identify the tenant`,
+		}
+		for lang, expected := range tests {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set(headerAcceptLanguage, lang)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			AppErrorHandler(&mockLogger{})(handler(c), c)
+
+			var body struct {
+				Message string `json:"message"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+			if body.Message != expected {
+				t.Errorf("%s: expected %q, got %q", lang, expected, body.Message)
+			}
+		}
+	})
+
 	t.Run("missing identity", func(t *testing.T) {
 		mw := EnrichmentMiddleware(true, "X-Tenant-ID", nil)
 		handler := mw(func(c echo.Context) error { return nil })
@@ -1,19 +1,58 @@
 package mw
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/nochebuenadev/go-kit/pkg/apperr"
 	"github.com/nochebuenadev/go-kit/pkg/logz"
+	"github.com/nochebuenadev/go-kit/pkg/metrics"
 )
 
+type (
+	// ErrorHandlerOption configures the handler returned by AppErrorHandler.
+	ErrorHandlerOption func(*errorHandler)
+
+	// errorHandler holds the settings of AppErrorHandler.
+	errorHandler struct {
+		// logger is used to log the handled errors.
+		logger logz.Logger
+		// problemDetails enables application/problem+json responses.
+		problemDetails bool
+		// problemTypeBase is the URI prefix of the problem types.
+		problemTypeBase string
+	}
+)
+
+// WithProblemDetails enables RFC 9457 responses: requests whose Accept header prefers
+// application/problem+json receive a ProblemDetails document instead of the AppErr JSON.
+// typeBase is the URI prefix of the problem types (e.g. "https://errors.example.com");
+// when empty every problem has the type "about:blank".
+func WithProblemDetails(typeBase string) ErrorHandlerOption {
+	return func(h *errorHandler) {
+		h.problemDetails = true
+		h.problemTypeBase = typeBase
+	}
+}
+
 // AppErrorHandler returns an Echo HTTPErrorHandler that standardizes error responses.
 // It maps apperr.AppErr to matching HTTP status codes and logs the errors using the provided logger.
-// It also handles echo.HTTPError by mapping them to apperr.AppErr.
-func AppErrorHandler(logger logz.Logger) echo.HTTPErrorHandler {
+// It also handles echo.HTTPError by mapping them to apperr.AppErr. Responses are translated to
+// the locale of the request (see WithLocale), while logs keep the original message. Responses
+// include only the public context of the error; its internal diagnostics are only logged.
+// Errors with a retry-after duration (apperr.AppErr.WithRetryAfter) set the Retry-After header.
+// Every response is counted in the error metrics by code, route template and method.
+func AppErrorHandler(logger logz.Logger, opts ...ErrorHandlerOption) echo.HTTPErrorHandler {
+	h := &errorHandler{logger: logger}
+	for _, opt := range opts {
+		opt(h)
+	}
+
 	return func(err error, c echo.Context) {
 		if c.Response().Committed {
 			return
@@ -29,91 +68,69 @@ func AppErrorHandler(logger logz.Logger) echo.HTTPErrorHandler {
 			}
 		}
 
+		requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+		ctx := mGetRequestContext(c)
+		args := []any{
+			"method", mGetRequestMethod(c),
+			"uri", mGetRequestURI(c),
+			"code", appErr.GetCode(),
+		}
+		if logz.GetRequestID(ctx) == "" {
+			// Without WithRequestID the context does not carry the request ID.
+			args = append(args, "request_id", requestID)
+		}
 		if appErr.GetCode() == string(apperr.ErrResourceNotFound) {
-			logger.With(
-				"request_id", c.Response().Header().Get(echo.HeaderXRequestID),
-				"method", mGetRequestMethod(c),
-				"uri", mGetRequestURI(c),
-				"code", appErr.GetCode(),
-			).Warn("mw: recurso no encontrado")
+			logger.With(args...).WarnCtx(ctx, "mw: recurso no encontrado")
 		} else {
-			logger.With(
-				"request_id", c.Response().Header().Get(echo.HeaderXRequestID),
-				"method", mGetRequestMethod(c),
-				"uri", mGetRequestURI(c),
-				"code", appErr.GetCode(),
-			).LogError("mw: petición fallida", appErr)
+			logger.With(args...).LogErrorCtx(ctx, "mw: petición fallida", appErr)
 		}
 
 		status := mapAppErrToHTTPStatus(appErr)
+		metrics.IncError(metrics.ErrorLabels{
+			Code:   appErr.GetCode(),
+			Route:  c.Path(),
+			Method: mGetRequestMethod(c),
+			Source: metrics.SourceHTTP,
+		})
+		if d := apperr.RetryAfterOf(appErr); d > 0 {
+			c.Response().Header().Set(echo.HeaderRetryAfter, retryAfterSeconds(d))
+		}
+		locale := requestLocale(c)
+		appErr = localize(appErr, locale)
+		c.Response().Header().Set(headerContentLanguage, string(locale))
+
+		if h.problemDetails {
+			// The body depends on the Accept header, so caches must key on it.
+			c.Response().Header().Add(echo.HeaderVary, echo.HeaderAccept)
+		}
+		if h.problemDetails && c.Request() != nil && acceptsProblemDetails(c.Request().Header.Get(echo.HeaderAccept)) {
+			body, err := json.Marshal(NewProblemDetails(appErr, status, requestID, h.problemTypeBase))
+			if err == nil {
+				_ = c.Blob(status, MIMEApplicationProblemJSON, body)
+				return
+			}
+			logger.ErrorCtx(ctx, "mw: error serializando problem details", err)
+		}
 
 		_ = c.JSON(status, appErr)
 	}
 }
 
-// mapHTTPStatusToAppErr converts an echo.HTTPError into our standardized apperr.AppErr.
-// It maps various HTTP status codes to the most appropriate internal ErrorCode.
+// mapHTTPStatusToAppErr converts an echo.HTTPError into our standardized apperr.AppErr,
+// using the code registered in apperr for its HTTP status.
 func mapHTTPStatusToAppErr(he *echo.HTTPError) *apperr.AppErr {
-	var code apperr.ErrorCode
-
-	switch he.Code {
-	case http.StatusNotFound:
-		code = apperr.ErrResourceNotFound
-	case http.StatusUnauthorized:
-		code = apperr.ErrUnauthorized
-	case http.StatusForbidden:
-		code = apperr.ErrPermissionDenied
-	case http.StatusBadRequest,
-		http.StatusUnprocessableEntity,
-		http.StatusRequestEntityTooLarge:
-		code = apperr.ErrInvalidInput
-	case http.StatusConflict:
-		code = apperr.ErrConflict
-	case http.StatusMethodNotAllowed:
-		code = apperr.ErrNotImplemented
-	case http.StatusRequestTimeout, http.StatusGatewayTimeout:
-		code = apperr.ErrDeadlineExceeded
-	case http.StatusServiceUnavailable:
-		code = apperr.ErrUnavailable
-	case http.StatusNotImplemented:
-		code = apperr.ErrNotImplemented
-	case http.StatusInternalServerError:
-		code = apperr.ErrInternal
-	default:
-		if he.Code >= 500 {
-			code = apperr.ErrInternal
-		} else {
-			code = apperr.ErrInvalidInput
-		}
-	}
-
+	code := apperr.FromHTTPStatus(he.Code)
 	return apperr.New(code, fmt.Sprintf("%v", he.Message)).WithError(he)
 }
 
-// mapAppErrToHTTPStatus maps an application error code to a standard HTTP status code.
+// retryAfterSeconds formats d as the delay-seconds of a Retry-After header, rounded up.
+func retryAfterSeconds(d time.Duration) string {
+	return strconv.FormatInt(int64((d+time.Second-1)/time.Second), 10)
+}
+
+// mapAppErrToHTTPStatus maps an application error code to the HTTP status registered in apperr.
 func mapAppErrToHTTPStatus(appErr *apperr.AppErr) int {
-	switch apperr.ErrorCode(appErr.GetCode()) {
-	case apperr.ErrInvalidInput:
-		return http.StatusBadRequest
-	case apperr.ErrResourceNotFound:
-		return http.StatusNotFound
-	case apperr.ErrConflict:
-		return http.StatusConflict
-	case apperr.ErrUnauthorized:
-		return http.StatusUnauthorized
-	case apperr.ErrPermissionDenied:
-		return http.StatusForbidden
-	case apperr.ErrInternal:
-		return http.StatusInternalServerError
-	case apperr.ErrNotImplemented:
-		return http.StatusNotImplemented
-	case apperr.ErrUnavailable:
-		return http.StatusServiceUnavailable
-	case apperr.ErrDeadlineExceeded:
-		return http.StatusGatewayTimeout
-	default:
-		return http.StatusInternalServerError
-	}
+	return apperr.ErrorCode(appErr.GetCode()).HTTPStatus()
 }
 
 // mGetRequestMethod safely retrieves the request method from echo.Context.
@@ -125,6 +142,15 @@ func mGetRequestMethod(c echo.Context) string {
 	return c.Request().Method
 }
 
+// mGetRequestContext safely retrieves the context of the request from echo.Context.
+// It returns context.Background if the request is not present.
+func mGetRequestContext(c echo.Context) context.Context {
+	if c.Request() == nil {
+		return context.Background()
+	}
+	return c.Request().Context()
+}
+
 // mGetRequestURI safely retrieves the request URI from echo.Context.
 // It returns "UNKNOWN" if the request is not present.
 func mGetRequestURI(c echo.Context) string {
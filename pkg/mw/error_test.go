@@ -4,25 +4,36 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"io"
+	"maps"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/nochebuenadev/go-kit/pkg/apperr"
 	"github.com/nochebuenadev/go-kit/pkg/logz"
+	"github.com/nochebuenadev/go-kit/pkg/metrics"
 )
 
 type mockLogger struct{}
 
-func (m *mockLogger) Debug(msg string, args ...any)               {}
-func (m *mockLogger) Info(msg string, args ...any)                {}
-func (m *mockLogger) Warn(msg string, args ...any)                {}
-func (m *mockLogger) Error(msg string, err error, args ...any)    {}
-func (m *mockLogger) LogError(msg string, err error, args ...any) {}
-func (m *mockLogger) Fatal(msg string, err error, args ...any)    {}
-func (m *mockLogger) With(args ...any) logz.Logger                { return m }
-func (m *mockLogger) WithContext(ctx context.Context) logz.Logger { return m }
+func (m *mockLogger) Debug(msg string, args ...any)                                       {}
+func (m *mockLogger) Info(msg string, args ...any)                                        {}
+func (m *mockLogger) Warn(msg string, args ...any)                                        {}
+func (m *mockLogger) Error(msg string, err error, args ...any)                            {}
+func (m *mockLogger) LogError(msg string, err error, args ...any)                         {}
+func (m *mockLogger) Fatal(msg string, err error, args ...any)                            {}
+func (m *mockLogger) DebugCtx(ctx context.Context, msg string, args ...any)               {}
+func (m *mockLogger) InfoCtx(ctx context.Context, msg string, args ...any)                {}
+func (m *mockLogger) WarnCtx(ctx context.Context, msg string, args ...any)                {}
+func (m *mockLogger) ErrorCtx(ctx context.Context, msg string, err error, args ...any)    {}
+func (m *mockLogger) LogErrorCtx(ctx context.Context, msg string, err error, args ...any) {}
+func (m *mockLogger) With(args ...any) logz.Logger                                        { return m }
+func (m *mockLogger) WithContext(ctx context.Context) logz.Logger                         { return m }
+func (m *mockLogger) Named(name string) logz.Logger                                       { return m }
 
 func TestAppErrorHandler(t *testing.T) {
 	e := echo.New()
@@ -129,6 +140,8 @@ func TestMapHTTPStatusToAppErr(t *testing.T) {
 		{http.StatusTeapot, apperr.ErrInvalidInput},
 		{http.StatusBadGateway, apperr.ErrInternal}, // default for 5xx
 		{503, apperr.ErrUnavailable},
+		{http.StatusTooManyRequests, apperr.ErrResourceExhausted},
+		{apperr.StatusClientClosedRequest, apperr.ErrCancelled},
 	}
 
 	for _, tt := range tests {
@@ -180,6 +193,9 @@ func TestMGetRequestHelpers(t *testing.T) {
 		if uri := mGetRequestURI(c); uri != "/test" {
 			t.Errorf("expected /test, got %s", uri)
 		}
+		if ctx := mGetRequestContext(c); ctx != req.Context() {
+			t.Error("expected the request context")
+		}
 	})
 
 	t.Run("without request", func(t *testing.T) {
@@ -190,5 +206,240 @@ func TestMGetRequestHelpers(t *testing.T) {
 		if uri := mGetRequestURI(c); uri != "UNKNOWN" {
 			t.Errorf("expected UNKNOWN, got %s", uri)
 		}
+		if ctx := mGetRequestContext(c); ctx == nil {
+			t.Error("expected a background context")
+		}
+	})
+}
+
+func TestAppErrorHandler_ProblemDetails(t *testing.T) {
+	e := echo.New()
+	appErr := apperr.NotFound("user %d not found", 7).WithContext("user_id", 7).WithContext("status", "ignored")
+
+	serve := func(handler echo.HTTPErrorHandler, accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/users/7", nil)
+		req.Header.Set(echo.HeaderAccept, accept)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Response().Header().Set(echo.HeaderXRequestID, "req-1")
+		handler(appErr, c)
+		return rec
+	}
+
+	t.Run("problem+json requested", func(t *testing.T) {
+		rec := serve(AppErrorHandler(&mockLogger{}, WithProblemDetails("https://errors.example.com/")), MIMEApplicationProblemJSON)
+
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("expected status %d, got %d", http.StatusNotFound, rec.Code)
+		}
+		if ct := rec.Header().Get(echo.HeaderContentType); ct != MIMEApplicationProblemJSON {
+			t.Errorf("expected content type %s, got %s", MIMEApplicationProblemJSON, ct)
+		}
+		if vary := rec.Header().Get(echo.HeaderVary); vary != echo.HeaderAccept {
+			t.Errorf("expected Vary %s, got %q", echo.HeaderAccept, vary)
+		}
+
+		var body map[string]any
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		expected := map[string]any{
+			"type":     "https://errors.example.com/not-found",
+			"title":    "Resource not found",
+			"status":   float64(http.StatusNotFound),
+			"detail":   "user 7 not found",
+			"instance": "req-1",
+			"code":     "NOT_FOUND",
+			"user_id":  float64(7),
+		}
+		for k, v := range expected {
+			if body[k] != v {
+				t.Errorf("member %s: expected %v, got %v", k, v, body[k])
+			}
+		}
+	})
+
+	t.Run("about:blank without type base", func(t *testing.T) {
+		rec := serve(AppErrorHandler(&mockLogger{}, WithProblemDetails("")), MIMEApplicationProblemJSON)
+
+		var body map[string]any
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		if body["type"] != "about:blank" || body["title"] != http.StatusText(http.StatusNotFound) {
+			t.Errorf("unexpected type or title: %v, %v", body["type"], body["title"])
+		}
 	})
+
+	t.Run("default shape", func(t *testing.T) {
+		for name, rec := range map[string]*httptest.ResponseRecorder{
+			"not enabled":    serve(AppErrorHandler(&mockLogger{}), MIMEApplicationProblemJSON),
+			"json preferred": serve(AppErrorHandler(&mockLogger{}, WithProblemDetails("")), "application/json"),
+		} {
+			if ct := rec.Header().Get(echo.HeaderContentType); ct == MIMEApplicationProblemJSON {
+				t.Errorf("%s: expected the AppErr JSON, got %s", name, ct)
+			}
+			if vary := rec.Header().Get(echo.HeaderVary); (name == "json preferred") != (vary == echo.HeaderAccept) {
+				t.Errorf("%s: unexpected Vary %q", name, vary)
+			}
+			var body map[string]any
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("%s: failed to unmarshal response: %v", name, err)
+			}
+			if body["code"] != "NOT_FOUND" || body["message"] != "user 7 not found" {
+				t.Errorf("%s: unexpected body %v", name, body)
+			}
+		}
+	})
+}
+
+func TestAcceptsProblemDetails(t *testing.T) {
+	tests := []struct {
+		accept   string
+		expected bool
+	}{
+		{"", false},
+		{"*/*", false},
+		{"application/json", false},
+		{"application/problem+json", true},
+		{"application/json, application/problem+json", true},
+		{"application/json;q=0.9, application/problem+json", true},
+		{"application/json, application/problem+json;q=0.5", false},
+		{"application/problem+json;q=0", false},
+	}
+
+	for _, tt := range tests {
+		if got := acceptsProblemDetails(tt.accept); got != tt.expected {
+			t.Errorf("Accept %q: expected %v, got %v", tt.accept, tt.expected, got)
+		}
+	}
+}
+
+func TestAppErrorHandler_FieldViolations(t *testing.T) {
+	e := echo.New()
+	appErr := apperr.InvalidInput("invalid data").WithFieldViolations(
+		apperr.FieldViolation{Field: "email", Tag: "required", Message: "email is required"},
+		apperr.FieldViolation{Field: "age", Tag: "min", Param: "18", Message: "age is too low"},
+	)
+	handler := AppErrorHandler(&mockLogger{}, WithProblemDetails(""))
+
+	for _, accept := range []string{echo.MIMEApplicationJSON, MIMEApplicationProblemJSON} {
+		t.Run(accept, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			req.Header.Set(echo.HeaderAccept, accept)
+			rec := httptest.NewRecorder()
+
+			handler(appErr, e.NewContext(req, rec))
+
+			var body struct {
+				Errors []apperr.FieldViolation `json:"errors"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+			if len(body.Errors) != 2 || body.Errors[0].Field != "email" || body.Errors[1].Param != "18" {
+				t.Errorf("unexpected errors array: %s", rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestAppErrorHandler_InternalDetails(t *testing.T) {
+	e := echo.New()
+	appErr := apperr.New(apperr.ErrConflict, "el registro ya existe").
+		WithPublic("field", "email").
+		WithInternal("constraint", "users_email_key")
+	handler := AppErrorHandler(&mockLogger{}, WithProblemDetails(""))
+
+	for _, accept := range []string{echo.MIMEApplicationJSON, MIMEApplicationProblemJSON} {
+		t.Run(accept, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			req.Header.Set(echo.HeaderAccept, accept)
+			rec := httptest.NewRecorder()
+
+			handler(appErr, e.NewContext(req, rec))
+
+			if strings.Contains(rec.Body.String(), "users_email_key") {
+				t.Errorf("expected internal details to be hidden, got %s", rec.Body.String())
+			}
+			if !strings.Contains(rec.Body.String(), `"field":"email"`) {
+				t.Errorf("expected public details in the response, got %s", rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestAppErrorHandler_RetryAfter(t *testing.T) {
+	e := echo.New()
+	handler := AppErrorHandler(&mockLogger{})
+
+	tests := []struct {
+		err      error
+		expected string
+	}{
+		{apperr.New(apperr.ErrResourceExhausted, "quota exceeded").WithRetryAfter(1500 * time.Millisecond), "2"},
+		{apperr.New(apperr.ErrUnavailable, "down"), ""},
+	}
+
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		handler(tt.err, e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec))
+
+		if got := rec.Header().Get(echo.HeaderRetryAfter); got != tt.expected {
+			t.Errorf("%v: expected Retry-After %q, got %q", tt.err, tt.expected, got)
+		}
+	}
+}
+
+func TestAppErrorHandler_Metrics(t *testing.T) {
+	mem := metrics.NewMemory()
+	metrics.SetErrorCounter(mem)
+	t.Cleanup(func() { metrics.SetErrorCounter(nil) })
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodDelete, "/users/7", nil)
+	c := e.NewContext(req, httptest.NewRecorder())
+	c.SetPath("/users/:id")
+
+	AppErrorHandler(&mockLogger{})(apperr.Internal("boom"), c)
+
+	expected := metrics.ErrorLabels{
+		Code:   "INTERNAL_ERROR",
+		Class:  metrics.ClassServer,
+		Route:  "/users/:id",
+		Method: http.MethodDelete,
+		Source: metrics.SourceHTTP,
+	}
+	if n := mem.Count(expected); n != 1 {
+		t.Errorf("expected the error to be counted once with %+v, got %v", expected, mem.Snapshot())
+	}
+}
+
+func TestAppErrorHandler_MetricsSources(t *testing.T) {
+	mem := metrics.NewMemory()
+	metrics.SetErrorCounter(mem)
+	t.Cleanup(func() { metrics.SetErrorCounter(nil) })
+
+	logz.MustInitWith(logz.WithSinks(logz.Sink{Writer: io.Discard}))
+	handler := AppErrorHandler(logz.Global())
+
+	e := echo.New()
+	for _, err := range []error{apperr.Internal("boom"), apperr.NotFound("missing")} {
+		c := e.NewContext(httptest.NewRequest(http.MethodGet, "/users/7", nil), httptest.NewRecorder())
+		handler(err, c)
+	}
+
+	tests := []struct {
+		source string
+		codes  map[string]uint64
+	}{
+		{metrics.SourceHTTP, map[string]uint64{"INTERNAL_ERROR": 1, "NOT_FOUND": 1}},
+		// NOT_FOUND is logged at WARN level, not with LogError
+		{metrics.SourceLog, map[string]uint64{"INTERNAL_ERROR": 1}},
+	}
+	for _, tt := range tests {
+		if got := mem.Report(tt.source).ByCode; !maps.Equal(got, tt.codes) {
+			t.Errorf("expected %v under %s, got %v", tt.codes, tt.source, got)
+		}
+	}
 }
@@ -0,0 +1,109 @@
+package mw
+
+import (
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nochebuenadev/go-kit/pkg/apperr"
+	"github.com/nochebuenadev/go-kit/pkg/i18n"
+)
+
+const (
+	// headerAcceptLanguage is the header with the languages preferred by the client.
+	headerAcceptLanguage = "Accept-Language"
+	// headerContentLanguage is the header with the language of the response.
+	headerContentLanguage = "Content-Language"
+)
+
+// WithLocale is a middleware that negotiates the locale of the request from its Accept-Language
+// header, among the locales of the i18n.Default catalogue, and stores it in the request context
+// using i18n.SetInContext. Handlers read it with i18n.FromContext or i18n.LocaleOrDefault.
+func WithLocale() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			locale := i18n.Default().Negotiate(c.Request().Header.Get(headerAcceptLanguage))
+
+			ctx := i18n.SetInContext(c.Request().Context(), locale)
+			c.SetRequest(c.Request().WithContext(ctx))
+			c.Response().Header().Add(echo.HeaderVary, headerAcceptLanguage)
+
+			return next(c)
+		}
+	}
+}
+
+// requestLocale returns the locale stored by WithLocale, or negotiates it from the
+// Accept-Language header when the middleware did not run (e.g. routing errors).
+func requestLocale(c echo.Context) i18n.Locale {
+	if c.Request() == nil {
+		return i18n.DefaultLocale
+	}
+	if locale, ok := i18n.FromContext(c.Request().Context()); ok {
+		return locale
+	}
+	return i18n.Default().Negotiate(c.Request().Header.Get(headerAcceptLanguage))
+}
+
+// localize returns the error as it must be shown in the given locale. Messages are written in
+// i18n.DefaultLocale, so for other locales the message is translated only when the catalogue
+// has it: a message rendered from the catalogue (apperr.AppErr.WithMessageKey), a fixed
+// message with an i18n.MessageKey, a message built by check from the field violations, or an
+// empty message, which takes the message of the error code. Any other
+// message, such as "el usuario 7 no existe", is kept as written. Field violations are always
+// translated from their tag. The original error is not modified.
+func localize(appErr *apperr.AppErr, locale i18n.Locale) *apperr.AppErr {
+	if locale.Base() == i18n.DefaultLocale {
+		return appErr
+	}
+
+	catalog := i18n.Default()
+	violations := appErr.GetFieldViolations()
+	translated := make([]apperr.FieldViolation, len(violations))
+	for i, v := range violations {
+		v.Message = catalog.TranslateTag(locale, v.Field, v.Tag, v.Param)
+		translated[i] = v
+	}
+
+	msg := localizeMessage(catalog, locale, appErr, violations, translated)
+	localized := apperr.New(apperr.ErrorCode(appErr.GetCode()), msg)
+	for k, v := range appErr.GetContext() {
+		if k != apperr.FieldViolationsKey {
+			localized = localized.WithContext(k, v)
+		}
+	}
+	if len(translated) > 0 {
+		localized = localized.WithFieldViolations(translated...)
+	}
+	return localized
+}
+
+// localizeMessage returns the message of appErr in locale, or the original message if the
+// catalogue cannot translate it. violations are the original field violations and translated
+// the same violations in locale.
+func localizeMessage(catalog *i18n.Catalog, locale i18n.Locale, appErr *apperr.AppErr, violations, translated []apperr.FieldViolation) string {
+	if key, params := appErr.GetMessageKey(); key != "" {
+		if _, ok := catalog.Lookup(locale, key); ok {
+			return catalog.Translate(locale, key, params)
+		}
+	}
+
+	msg := appErr.GetMessage()
+	if t, ok := catalog.Lookup(locale, i18n.MessageKey(msg)); ok {
+		return t
+	}
+
+	switch {
+	case msg == "":
+		if t, ok := catalog.Lookup(locale, i18n.CodeKey(appErr.GetCode())); ok {
+			return t
+		}
+	case len(violations) == 1 && msg == violations[0].Message:
+		return translated[0].Message
+	case len(violations) > 1:
+		params := map[string]string{"count": strconv.Itoa(len(violations))}
+		if msg == catalog.Translate(i18n.DefaultLocale, i18n.SummaryKey, params) {
+			return catalog.Translate(locale, i18n.SummaryKey, params)
+		}
+	}
+	return msg
+}
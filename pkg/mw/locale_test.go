@@ -0,0 +1,134 @@
+package mw
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nochebuenadev/go-kit/pkg/apperr"
+	"github.com/nochebuenadev/go-kit/pkg/i18n"
+)
+
+func TestWithLocale(t *testing.T) {
+	e := echo.New()
+	mw := WithLocale()
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(headerAcceptLanguage, "en-US,en;q=0.9,es;q=0.8")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	handler := mw(func(c echo.Context) error {
+		locale, ok := i18n.FromContext(c.Request().Context())
+		if !ok || locale != i18n.English {
+			t.Errorf("expected locale %s in context, got %q", i18n.English, locale)
+		}
+		return c.NoContent(http.StatusOK)
+	})
+
+	if err := handler(c); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if vary := rec.Header().Get(echo.HeaderVary); vary != headerAcceptLanguage {
+		t.Errorf("expected Vary %s, got %q", headerAcceptLanguage, vary)
+	}
+}
+
+func TestAppErrorHandler_Localized(t *testing.T) {
+	e := echo.New()
+	handler := AppErrorHandler(&mockLogger{})
+	appErr := apperr.NotFound("el usuario %d no existe", 7).
+		WithContext("user_id", 7).
+		WithFieldViolations(apperr.FieldViolation{Field: "email", Tag: "required", Message: "El campo 'email' es obligatorio"})
+
+	tests := []struct {
+		name            string
+		acceptLanguage  string
+		expectedLang    string
+		expectedMessage string
+		expectedField   string
+	}{
+		{"default locale keeps the message", "", "es", "el usuario 7 no existe", "El campo 'email' es obligatorio"},
+		{"spanish keeps the message", "es-MX", "es", "el usuario 7 no existe", "El campo 'email' es obligatorio"},
+		{"english keeps a specific message", "en-US", "en", "el usuario 7 no existe", "Field 'email' is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set(headerAcceptLanguage, tt.acceptLanguage)
+			rec := httptest.NewRecorder()
+
+			handler(appErr, e.NewContext(req, rec))
+
+			if lang := rec.Header().Get(headerContentLanguage); lang != tt.expectedLang {
+				t.Errorf("expected Content-Language %s, got %s", tt.expectedLang, lang)
+			}
+
+			var body struct {
+				Message string                  `json:"message"`
+				Context map[string]any          `json:"context"`
+				Errors  []apperr.FieldViolation `json:"errors"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+			if body.Message != tt.expectedMessage {
+				t.Errorf("expected message %q, got %q", tt.expectedMessage, body.Message)
+			}
+			if len(body.Errors) != 1 || body.Errors[0].Message != tt.expectedField {
+				t.Errorf("expected violation message %q, got %v", tt.expectedField, body.Errors)
+			}
+			if body.Context["user_id"] != float64(7) {
+				t.Errorf("expected context to be kept, got %v", body.Context)
+			}
+		})
+	}
+
+	t.Run("locale from context wins", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(headerAcceptLanguage, "es")
+		req = req.WithContext(i18n.SetInContext(req.Context(), i18n.English))
+		rec := httptest.NewRecorder()
+
+		handler(appErr, e.NewContext(req, rec))
+
+		if lang := rec.Header().Get(headerContentLanguage); lang != "en" {
+			t.Errorf("expected Content-Language en, got %s", lang)
+		}
+	})
+
+	if appErr.GetMessage() != "el usuario 7 no existe" {
+		t.Errorf("expected the original error to be left untouched, got %s", appErr.GetMessage())
+	}
+}
+
+func TestLocalize(t *testing.T) {
+	required := apperr.FieldViolation{Field: "email", Tag: "required", Message: "El campo 'email' es obligatorio"}
+	minLen := apperr.FieldViolation{Field: "name", Tag: "min", Param: "2", Message: "El campo 'name' es demasiado corto (mínimo 2)"}
+	tooLarge := apperr.InvalidInput("El cuerpo de la petición supera el límite de 8 bytes").WithMessageKey(
+		i18n.MessageKey("El cuerpo de la petición supera el límite de {max_body_size} bytes"), map[string]string{"max_body_size": "8"})
+
+	tests := []struct {
+		name     string
+		err      *apperr.AppErr
+		expected string
+	}{
+		{"specific message", apperr.New(apperr.ErrConflict, "el pedido 7 ya fue pagado"), "el pedido 7 ya fue pagado"},
+		{"message with a key", apperr.InvalidInput("Datos de entrada no válidos"), "Invalid input data"},
+		{"empty message", apperr.New(apperr.ErrResourceNotFound, ""), "Resource not found"},
+		{"message with a key and params", tooLarge, "The request body exceeds the limit of 8 bytes"},
+		{"single violation", apperr.InvalidInput("El campo 'email' es obligatorio").WithFieldViolations(required), "Field 'email' is required"},
+		{"several violations", apperr.InvalidInput("Los datos de entrada contienen 2 campos no válidos").WithFieldViolations(required, minLen), "The request contains 2 invalid fields"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := localize(tt.err, i18n.English).GetMessage(); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
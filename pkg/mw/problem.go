@@ -0,0 +1,123 @@
+package mw
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nochebuenadev/go-kit/pkg/apperr"
+)
+
+// MIMEApplicationProblemJSON is the media type of RFC 9457 problem details.
+const MIMEApplicationProblemJSON = "application/problem+json"
+
+type (
+	// ProblemDetails is an RFC 9457 problem details object.
+	ProblemDetails struct {
+		// Type is a URI identifying the problem type ("about:blank" when it has no URI of its own).
+		Type string
+		// Title is a short summary of the problem type.
+		Title string
+		// Status is the HTTP status code of the response.
+		Status int
+		// Detail is the explanation of this occurrence of the problem.
+		Detail string
+		// Instance identifies this occurrence of the problem (the request ID).
+		Instance string
+		// Extensions are additional members serialized next to the standard ones.
+		Extensions map[string]any
+	}
+)
+
+// reservedMembers are the problem details members that extensions cannot override.
+var reservedMembers = map[string]bool{
+	"type":     true,
+	"title":    true,
+	"status":   true,
+	"detail":   true,
+	"instance": true,
+	"code":     true,
+}
+
+// NewProblemDetails builds the problem details of an application error. The type is typeBase
+// followed by the code in kebab case (e.g. "https://errors.example.com/not-found"), or
+// "about:blank" with the HTTP status text as title when typeBase is empty. The error code and
+// its context are added as extension members.
+func NewProblemDetails(appErr *apperr.AppErr, status int, instance, typeBase string) *ProblemDetails {
+	code := apperr.ErrorCode(appErr.GetCode())
+
+	p := &ProblemDetails{
+		Type:       "about:blank",
+		Title:      http.StatusText(status),
+		Status:     status,
+		Detail:     appErr.GetMessage(),
+		Instance:   instance,
+		Extensions: map[string]any{"code": string(code)},
+	}
+	if typeBase != "" {
+		slug := strings.ToLower(strings.ReplaceAll(string(code), "_", "-"))
+		p.Type = strings.TrimSuffix(typeBase, "/") + "/" + slug
+		p.Title = code.Description()
+	}
+
+	for k, v := range appErr.GetContext() {
+		if !reservedMembers[k] {
+			p.Extensions[k] = v
+		}
+	}
+	return p
+}
+
+// MarshalJSON implements the json.Marshaler interface. Extensions are flattened into the
+// object, and empty optional members are omitted.
+func (p *ProblemDetails) MarshalJSON() ([]byte, error) {
+	m := make(map[string]any, len(p.Extensions)+5)
+	for k, v := range p.Extensions {
+		m[k] = v
+	}
+
+	m["type"] = p.Type
+	m["status"] = p.Status
+	if p.Title != "" {
+		m["title"] = p.Title
+	}
+	if p.Detail != "" {
+		m["detail"] = p.Detail
+	}
+	if p.Instance != "" {
+		m["instance"] = p.Instance
+	}
+	return json.Marshal(m)
+}
+
+// acceptsProblemDetails reports whether the Accept header prefers application/problem+json.
+// It must be listed explicitly with a quality not lower than application/json.
+func acceptsProblemDetails(accept string) bool {
+	problemQ, jsonQ := -1.0, -1.0
+
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+
+		switch mediaType {
+		case MIMEApplicationProblemJSON:
+			problemQ = max(problemQ, q)
+		case echo.MIMEApplicationJSON:
+			jsonQ = max(jsonQ, q)
+		}
+	}
+
+	return problemQ > 0 && problemQ >= jsonQ
+}
@@ -1,6 +1,7 @@
 package mw
 
 import (
+	"context"
 	"sync"
 
 	"github.com/labstack/echo/v4"
@@ -13,13 +14,22 @@ type (
 	Authorizer interface {
 		// Guard returns a middleware that checks if the authenticated user has the required permission bit.
 		Guard(requiredBit int64) echo.MiddlewareFunc
+		// OnReload reloads the application settings. It implements launcher.Reloadable.
+		OnReload(ctx context.Context) error
 	}
 
+	// AuthorizerOption configures an Authorizer created with NewAuthorizer.
+	AuthorizerOption func(*rbacComponent)
+
 	// rbacComponent is the concrete implementation of Authorizer.
 	rbacComponent struct {
 		logger   logz.Logger
 		provider authz.PermissionProvider
 		appID    string
+		// mu guards appID, which can be replaced on reload.
+		mu sync.RWMutex
+		// loadAppID reloads the application ID on OnReload.
+		loadAppID func() (string, error)
 	}
 )
 
@@ -30,19 +40,43 @@ var (
 	rbacOnce sync.Once
 )
 
+// WithAppIDLoader sets the function used by OnReload to read the application ID again.
+func WithAppIDLoader(load func() (string, error)) AuthorizerOption {
+	return func(r *rbacComponent) {
+		r.loadAppID = load
+	}
+}
+
+// NewAuthorizer creates a new, independent Authorizer. Unlike GetAuthorizer it can be called
+// several times, e.g. to guard routes of different applications in the same service.
+func NewAuthorizer(logger logz.Logger, provider authz.PermissionProvider, appID string, opts ...AuthorizerOption) Authorizer {
+	r := &rbacComponent{
+		logger:   logger,
+		provider: provider,
+		appID:    appID,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
 // GetAuthorizer returns the singleton instance of the Authorizer.
 func GetAuthorizer(logger logz.Logger, provider authz.PermissionProvider, appID string) Authorizer {
 	rbacOnce.Do(func() {
-		rbacInstance = &rbacComponent{
-			logger:   logger,
-			provider: provider,
-			appID:    appID,
-		}
+		rbacInstance = NewAuthorizer(logger, provider, appID)
 	})
 
 	return rbacInstance
 }
 
+// ResetAuthorizer discards the singleton returned by GetAuthorizer so the next call builds a new one.
+// It is intended for tests and must not be called concurrently with GetAuthorizer.
+func ResetAuthorizer() {
+	rbacInstance = nil
+	rbacOnce = sync.Once{}
+}
+
 // Guard implements the Authorizer interface.
 // It resolves the user's permission mask and checks the required bit.
 func (r *rbacComponent) Guard(requiredBit int64) echo.MiddlewareFunc {
@@ -52,14 +86,15 @@ func (r *rbacComponent) Guard(requiredBit int64) echo.MiddlewareFunc {
 
 			id, ok := authz.FromContext(ctx)
 			if !ok {
-				r.logger.Warn("mw: intento de acceso RBAC sin identidad en el contexto")
+				r.logger.WarnCtx(ctx, "mw: intento de acceso RBAC sin identidad en el contexto")
 				return echo.ErrUnauthorized
 			}
 
-			mask, err := r.provider.ResolveMask(ctx, id.UID, id.TenantID, r.appID)
+			appID := r.currentAppID()
+			mask, err := r.provider.ResolveMask(ctx, id.UID, id.TenantID, appID)
 			if err != nil {
-				r.logger.LogError("mw: el proveedor de permisos falló al resolver acceso", err,
-					"uid", id.UID, "tenant_id", id.TenantID, "app_id", r.appID)
+				r.logger.LogErrorCtx(ctx, "mw: el proveedor de permisos falló al resolver acceso", err,
+					"uid", id.UID, "tenant_id", id.TenantID, "app_id", appID)
 				return echo.ErrForbidden
 			}
 
@@ -71,3 +106,30 @@ func (r *rbacComponent) Guard(requiredBit int64) echo.MiddlewareFunc {
 		}
 	}
 }
+
+// OnReload implements the Authorizer interface. It reads the application ID again with the
+// loader set by WithAppIDLoader. Without a loader it does nothing.
+func (r *rbacComponent) OnReload(ctx context.Context) error {
+	if r.loadAppID == nil {
+		return nil
+	}
+
+	appID, err := r.loadAppID()
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	r.appID = appID
+	r.mu.Unlock()
+
+	r.logger.Info("mw: configuración RBAC recargada", "app_id", appID)
+	return nil
+}
+
+// currentAppID returns the application ID in use.
+func (r *rbacComponent) currentAppID() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.appID
+}
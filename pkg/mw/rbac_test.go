@@ -5,7 +5,6 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
-	"sync"
 	"testing"
 
 	"github.com/labstack/echo/v4"
@@ -21,14 +20,24 @@ func (m *mockPermProvider) ResolveMask(ctx context.Context, uid, tenantID, appID
 	return m.mask, m.err
 }
 
+func TestNewAuthorizer(t *testing.T) {
+	a1 := NewAuthorizer(&mockLogger{}, &mockPermProvider{}, "app-1")
+	a2 := NewAuthorizer(&mockLogger{}, &mockPermProvider{}, "app-2")
+	if a1 == a2 {
+		t.Fatal("expected independent instances")
+	}
+	if a2.(*rbacComponent).appID != "app-2" {
+		t.Errorf("expected app-2, got %s", a2.(*rbacComponent).appID)
+	}
+}
+
 func TestAuthorizer_Guard(t *testing.T) {
 	e := echo.New()
 	logger := &mockLogger{}
 
 	t.Run("no identity", func(t *testing.T) {
 		// Reset singleton
-		rbacInstance = nil
-		rbacOnce = sync.Once{}
+		ResetAuthorizer()
 
 		auth := GetAuthorizer(logger, &mockPermProvider{}, "app-1")
 		handler := auth.Guard(1)(func(c echo.Context) error { return nil })
@@ -44,8 +53,7 @@ func TestAuthorizer_Guard(t *testing.T) {
 	})
 
 	t.Run("provider failure", func(t *testing.T) {
-		rbacInstance = nil
-		rbacOnce = sync.Once{}
+		ResetAuthorizer()
 
 		auth := GetAuthorizer(logger, &mockPermProvider{err: errors.New("fail")}, "app-1")
 		handler := auth.Guard(1)(func(c echo.Context) error { return nil })
@@ -64,8 +72,7 @@ func TestAuthorizer_Guard(t *testing.T) {
 	})
 
 	t.Run("no permission", func(t *testing.T) {
-		rbacInstance = nil
-		rbacOnce = sync.Once{}
+		ResetAuthorizer()
 
 		auth := GetAuthorizer(logger, &mockPermProvider{mask: 0}, "app-1")
 		handler := auth.Guard(1)(func(c echo.Context) error { return nil })
@@ -84,8 +91,7 @@ func TestAuthorizer_Guard(t *testing.T) {
 	})
 
 	t.Run("success", func(t *testing.T) {
-		rbacInstance = nil
-		rbacOnce = sync.Once{}
+		ResetAuthorizer()
 
 		auth := GetAuthorizer(logger, &mockPermProvider{mask: 2}, "app-1") // Bit 1 set (2^1 = 2)
 		handler := auth.Guard(1)(func(c echo.Context) error { return c.NoContent(http.StatusOK) })
@@ -105,3 +111,42 @@ func TestAuthorizer_Guard(t *testing.T) {
 		}
 	})
 }
+
+// appRecordingProvider records the application ID of the last resolution.
+type appRecordingProvider struct {
+	appID string
+}
+
+func (p *appRecordingProvider) ResolveMask(ctx context.Context, uid, tenantID, appID string) (int64, error) {
+	p.appID = appID
+	return 2, nil
+}
+
+func TestAuthorizer_OnReload(t *testing.T) {
+	e := echo.New()
+	provider := &appRecordingProvider{}
+	auth := NewAuthorizer(&mockLogger{}, provider, "app-1", WithAppIDLoader(func() (string, error) {
+		return "app-2", nil
+	}))
+
+	guard := func() {
+		handler := auth.Guard(1)(func(c echo.Context) error { return nil })
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req = req.WithContext(authz.SetInContext(context.Background(), &authz.Identity{UID: "u1"}))
+		_ = handler(e.NewContext(req, httptest.NewRecorder()))
+	}
+
+	guard()
+	if provider.appID != "app-1" {
+		t.Fatalf("expected app-1 before reload, got %s", provider.appID)
+	}
+
+	if err := auth.OnReload(context.Background()); err != nil {
+		t.Fatalf("OnReload failed: %v", err)
+	}
+
+	guard()
+	if provider.appID != "app-2" {
+		t.Errorf("expected app-2 after reload, got %s", provider.appID)
+	}
+}
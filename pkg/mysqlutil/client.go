@@ -3,7 +3,9 @@ package mysqlutil
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"errors"
+	"net"
 	"sync"
 	"time"
 
@@ -21,8 +23,15 @@ type (
 		cfg    *Config
 		db     *sql.DB
 		mu     sync.RWMutex
+		// initOnce ensures the connection pool is created only once per component.
+		initOnce sync.Once
+		// name identifies the component in health reports and the launcher.
+		name string
 	}
 
+	// Option configures a database component created with New.
+	Option func(*mysqlComponent)
+
 	// mysqlResult wraps sql.Result to satisfy the dbutil.Result interface.
 	mysqlResult struct {
 		res sql.Result
@@ -51,22 +60,48 @@ var (
 	clientInstance dbutil.Component
 	// clientOnce ensures the component is initialized only once.
 	clientOnce sync.Once
-	// clientInitOnce ensures the connection pool is created only once.
-	clientInitOnce sync.Once
 )
 
+// WithName sets the name reported by the component (defaults to "mysql").
+// Use it to tell several pools apart in health reports.
+func WithName(name string) Option {
+	return func(c *mysqlComponent) {
+		c.name = name
+	}
+}
+
+// New creates a new, independent MySQL database component. Unlike GetMySQLClient it can be
+// called several times to manage multiple pools in the same service.
+func New(logger logz.Logger, config *Config, opts ...Option) dbutil.Component {
+	c := &mysqlComponent{
+		cfg:    config,
+		logger: logger,
+		name:   "mysql",
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
 // GetMySQLClient returns the singleton instance of the MySQL database component.
 func GetMySQLClient(logger logz.Logger, config *Config) dbutil.Component {
 	clientOnce.Do(func() {
-		clientInstance = &mysqlComponent{
-			cfg:    config,
-			logger: logger,
-		}
+		clientInstance = New(logger, config)
 	})
 	return clientInstance
 }
 
+// Reset discards the singleton returned by GetMySQLClient so the next call builds a new one.
+// It is intended for tests and must not be called concurrently with GetMySQLClient.
+func Reset() {
+	clientInstance = nil
+	clientOnce = sync.Once{}
+}
+
 // HandleError maps MySQL errors to standard application errors (apperr).
+// Deadlocks and lock wait timeouts are mapped to a retryable ErrAborted, and connection errors
+// to a temporary ErrUnavailable, so callers can check apperr.IsRetryable.
 func HandleError(err error) error {
 	if err == nil {
 		return nil
@@ -79,9 +114,19 @@ func HandleError(err error) error {
 			return apperr.New(apperr.ErrConflict, "el registro ya existe").WithError(err)
 		case 1216, 1217, 1451, 1452: // Foreign key violations
 			return apperr.New(apperr.ErrInvalidInput, "violación de integridad de datos").WithError(err)
+		case 1205, 1213: // ER_LOCK_WAIT_TIMEOUT, ER_LOCK_DEADLOCK
+			return apperr.New(apperr.ErrAborted, "conflicto de concurrencia en la base de datos").
+				WithInternal("error_number", mysqlErr.Number).WithRetryable(true).WithError(err)
+		case 1040, 1053: // ER_CON_COUNT_ERROR, ER_SERVER_SHUTDOWN
+			return unavailable(err)
 		}
 	}
 
+	var netErr *net.OpError
+	if errors.Is(err, mysql.ErrInvalidConn) || errors.Is(err, driver.ErrBadConn) || errors.As(err, &netErr) {
+		return unavailable(err)
+	}
+
 	if errors.Is(err, sql.ErrNoRows) {
 		return apperr.NotFound("registro no encontrado").WithError(err)
 	}
@@ -89,10 +134,15 @@ func HandleError(err error) error {
 	return apperr.Internal("error inesperado en la base de datos MySQL").WithError(err)
 }
 
+// unavailable maps a connection error to a temporary ErrUnavailable.
+func unavailable(err error) error {
+	return apperr.New(apperr.ErrUnavailable, "base de datos no disponible").WithTemporary().WithError(err)
+}
+
 // OnInit implements the launcher.Component interface to initialize the database pool.
 func (c *mysqlComponent) OnInit() error {
 	var initErr error
-	clientInitOnce.Do(func() {
+	c.initOnce.Do(func() {
 		db, err := sql.Open("mysql", c.cfg.GetConnectionString())
 		if err != nil {
 			c.logger.Error("mysqlutil: error al abrir la conexión de MySQL", err)
@@ -258,7 +308,7 @@ func (c *mysqlComponent) HealthCheck(ctx context.Context) error {
 
 // Name implements health.Checkable.
 func (c *mysqlComponent) Name() string {
-	return "mysql"
+	return c.name
 }
 
 // Priority implements health.Checkable.
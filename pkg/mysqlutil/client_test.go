@@ -2,9 +2,19 @@ package mysqlutil
 
 import (
 	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/go-sql-driver/mysql"
+	"github.com/labstack/echo/v4"
+	"github.com/nochebuenadev/go-kit/pkg/apperr"
 	"github.com/nochebuenadev/go-kit/pkg/logz"
+	"github.com/nochebuenadev/go-kit/pkg/mw"
 )
 
 // mockLogger is a simple mock for logz.Logger.
@@ -12,14 +22,16 @@ type mockLogger struct {
 	logz.Logger
 }
 
-func (m *mockLogger) Debug(msg string, args ...any)               {}
-func (m *mockLogger) Info(msg string, args ...any)                {}
-func (m *mockLogger) Warn(msg string, args ...any)                {}
-func (m *mockLogger) Error(msg string, err error, args ...any)    {}
-func (m *mockLogger) LogError(msg string, err error, args ...any) {}
-func (m *mockLogger) Fatal(msg string, err error, args ...any)    {}
-func (m *mockLogger) With(args ...any) logz.Logger                { return m }
-func (m *mockLogger) WithContext(ctx context.Context) logz.Logger { return m }
+func (m *mockLogger) Debug(msg string, args ...any)                                       {}
+func (m *mockLogger) Info(msg string, args ...any)                                        {}
+func (m *mockLogger) Warn(msg string, args ...any)                                        {}
+func (m *mockLogger) Error(msg string, err error, args ...any)                            {}
+func (m *mockLogger) LogError(msg string, err error, args ...any)                         {}
+func (m *mockLogger) Fatal(msg string, err error, args ...any)                            {}
+func (m *mockLogger) WarnCtx(ctx context.Context, msg string, args ...any)                {}
+func (m *mockLogger) LogErrorCtx(ctx context.Context, msg string, err error, args ...any) {}
+func (m *mockLogger) With(args ...any) logz.Logger                                        { return m }
+func (m *mockLogger) WithContext(ctx context.Context) logz.Logger                         { return m }
 
 func TestGetMySQLClient(t *testing.T) {
 	cfg := &Config{Host: "localhost", Port: 3306}
@@ -36,6 +48,23 @@ func TestGetMySQLClient(t *testing.T) {
 	}
 }
 
+func TestNew(t *testing.T) {
+	logger := &mockLogger{}
+
+	c1 := New(logger, &Config{Host: "main"})
+	c2 := New(logger, &Config{Host: "reports"})
+	if c1 == c2 {
+		t.Fatal("expected independent instances")
+	}
+	if c1.(*mysqlComponent).cfg.Host != "main" || c2.(*mysqlComponent).cfg.Host != "reports" {
+		t.Error("expected each instance to keep its own config")
+	}
+
+	if c3 := New(logger, &Config{}, WithName("reports")); c3.Name() != "reports" {
+		t.Errorf("expected name reports, got %s", c3.Name())
+	}
+}
+
 func TestConfig_GetConnectionString(t *testing.T) {
 	cfg := &Config{
 		Host:     "localhost",
@@ -50,3 +79,69 @@ func TestConfig_GetConnectionString(t *testing.T) {
 		t.Errorf("expected %s, got %s", expected, cfg.GetConnectionString())
 	}
 }
+
+func TestHandleError(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		code      apperr.ErrorCode
+		retryable bool
+	}{
+		{"duplicate entry", &mysql.MySQLError{Number: 1062}, apperr.ErrConflict, false},
+		{"deadlock", &mysql.MySQLError{Number: 1213}, apperr.ErrAborted, true},
+		{"lock wait timeout", &mysql.MySQLError{Number: 1205}, apperr.ErrAborted, true},
+		{"too many connections", &mysql.MySQLError{Number: 1040}, apperr.ErrUnavailable, true},
+		{"invalid connection", mysql.ErrInvalidConn, apperr.ErrUnavailable, true},
+		{"bad connection", driver.ErrBadConn, apperr.ErrUnavailable, true},
+		{"no rows", sql.ErrNoRows, apperr.ErrResourceNotFound, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := HandleError(tt.err)
+			if !apperr.IsCode(err, tt.code) {
+				t.Errorf("expected %s, got %v", tt.code, err)
+			}
+			if apperr.IsRetryable(err) != tt.retryable {
+				t.Errorf("expected retryable %v, got %v", tt.retryable, !tt.retryable)
+			}
+		})
+	}
+
+	if HandleError(nil) != nil {
+		t.Error("expected nil, got error")
+	}
+}
+
+func TestHandleError_Localized(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected string
+	}{
+		{&mysql.MySQLError{Number: 1062}, "the record already exists"},
+		{&mysql.MySQLError{Number: 1452}, "data integrity violation"},
+		{&mysql.MySQLError{Number: 1213}, "database concurrency conflict"},
+		{driver.ErrBadConn, "database unavailable"},
+		{sql.ErrNoRows, "record not found"},
+		{errors.New("boom"), "unexpected MySQL database error"},
+	}
+
+	handler := mw.AppErrorHandler(&mockLogger{})
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Accept-Language", "en")
+		rec := httptest.NewRecorder()
+
+		handler(HandleError(tt.err), echo.New().NewContext(req, rec))
+
+		var body struct {
+			Message string `json:"message"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		if body.Message != tt.expected {
+			t.Errorf("%v: expected %q, got %q", tt.err, tt.expected, body.Message)
+		}
+	}
+}
@@ -6,6 +6,7 @@
 //
 // Features:
 // - Singleton database client with dbutil.Component implementation.
+// - Independent instances via New, to run several pools in the same service.
 // - Specialized wrappers for sql.Row, sql.Rows, and sql.Tx to satisfy agnostic interfaces.
 // - Standardized error handling (Duplicate entries, integrity violations, etc.).
 // - Configurable connection pool using environment variables.
@@ -3,6 +3,7 @@ package pgutil
 import (
 	"context"
 	"errors"
+	"net"
 	"sync"
 	"time"
 
@@ -27,8 +28,15 @@ type (
 		pool *pgxpool.Pool
 		// mu protects access to the pool instance during lifecycle changes.
 		mu sync.RWMutex
+		// initOnce ensures the connection pool is created only once per component.
+		initOnce sync.Once
+		// name identifies the component in health reports and the launcher.
+		name string
 	}
 
+	// Option configures a database component created with New.
+	Option func(*pgComponent)
+
 	// pgResult wraps pgconn.CommandTag to satisfy the dbutil.Result interface.
 	pgResult struct {
 		// tag is the underlying pgx result tag.
@@ -65,23 +73,50 @@ var (
 	clientInstance dbutil.Component
 	// clientOnce ensures the component is initialized only once.
 	clientOnce sync.Once
-	// clientInitOnce ensures the connection pool is created only once.
-	clientInitOnce sync.Once
 )
 
+// WithName sets the name reported by the component (defaults to "postgres").
+// Use it to tell several pools apart in health reports.
+func WithName(name string) Option {
+	return func(c *pgComponent) {
+		c.name = name
+	}
+}
+
+// New creates a new, independent database component. Unlike GetPostgresClient it can be
+// called several times (e.g. with configs loaded under different prefixes) to manage
+// multiple pools in the same service.
+func New(logger logz.Logger, config *Config, opts ...Option) dbutil.Component {
+	c := &pgComponent{
+		cfg:    config,
+		logger: logger,
+		name:   "postgres",
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
 // GetPostgresClient returns the singleton instance of the database component.
 func GetPostgresClient(logger logz.Logger, config *Config) dbutil.Component {
 	clientOnce.Do(func() {
-		clientInstance = &pgComponent{
-			cfg:    config,
-			logger: logger,
-		}
+		clientInstance = New(logger, config)
 	})
 	return clientInstance
 }
 
+// Reset discards the singleton returned by GetPostgresClient so the next call builds a new one.
+// It is intended for tests and must not be called concurrently with GetPostgresClient.
+func Reset() {
+	clientInstance = nil
+	clientOnce = sync.Once{}
+}
+
 // HandleError maps PostgreSQL and pgx errors to standard application errors (apperr).
 // It identifies unique violations, foreign key violations, and no-rows-found scenarios.
+// Serialization failures and deadlocks are mapped to a retryable ErrAborted, and connection
+// errors to a temporary ErrUnavailable, so callers can check apperr.IsRetryable.
 func HandleError(err error) error {
 	if err == nil {
 		return nil
@@ -92,13 +127,27 @@ func HandleError(err error) error {
 		switch pgErr.Code {
 		case pgerrcode.UniqueViolation:
 			return apperr.New(apperr.ErrConflict, "el registro ya existe").
-				WithContext("constraint", pgErr.ConstraintName).WithError(err)
+				WithInternal("constraint", pgErr.ConstraintName).WithError(err)
 		case pgerrcode.ForeignKeyViolation:
 			return apperr.New(apperr.ErrInvalidInput, "violación de integridad de datos").
-				WithContext("table", pgErr.TableName).WithError(err)
+				WithInternal("table", pgErr.TableName).WithError(err)
+		case pgerrcode.SerializationFailure, pgerrcode.DeadlockDetected:
+			return apperr.New(apperr.ErrAborted, "conflicto de concurrencia en la base de datos").
+				WithInternal("sqlstate", pgErr.Code).WithRetryable(true).WithError(err)
+		case pgerrcode.TooManyConnections, pgerrcode.AdminShutdown, pgerrcode.CrashShutdown, pgerrcode.CannotConnectNow:
+			return unavailable(err)
+		}
+		if pgerrcode.IsConnectionException(pgErr.Code) {
+			return unavailable(err)
 		}
 	}
 
+	var connErr *pgconn.ConnectError
+	var netErr *net.OpError
+	if errors.As(err, &connErr) || errors.As(err, &netErr) || pgconn.SafeToRetry(err) {
+		return unavailable(err)
+	}
+
 	if errors.Is(err, pgx.ErrNoRows) {
 		return apperr.NotFound("registro no encontrado").WithError(err)
 	}
@@ -106,10 +155,15 @@ func HandleError(err error) error {
 	return apperr.Internal("error inesperado en la base de datos").WithError(err)
 }
 
+// unavailable maps a connection error to a temporary ErrUnavailable.
+func unavailable(err error) error {
+	return apperr.New(apperr.ErrUnavailable, "base de datos no disponible").WithTemporary().WithError(err)
+}
+
 // OnInit implements the launcher.Component interface to initialize the database pool.
 func (c *pgComponent) OnInit() error {
 	var initErr error
-	clientInitOnce.Do(func() {
+	c.initOnce.Do(func() {
 		poolConfig, err := c.getPoolConfig()
 		if err != nil {
 			initErr = err
@@ -269,7 +323,7 @@ func (c *pgComponent) getInternalPool() (*pgxpool.Pool, error) {
 func (c *pgComponent) HealthCheck(ctx context.Context) error { return c.Ping(ctx) }
 
 // Name implements the health.Checkable interface.
-func (c *pgComponent) Name() string { return "postgres" }
+func (c *pgComponent) Name() string { return c.name }
 
 // Priority implements the health.Checkable interface.
 func (c *pgComponent) Priority() health.Level { return health.LevelCritical }
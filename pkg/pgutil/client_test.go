@@ -2,10 +2,20 @@ package pgutil
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/labstack/echo/v4"
 	"github.com/nochebuenadev/go-kit/pkg/apperr"
 	"github.com/nochebuenadev/go-kit/pkg/logz"
+	"github.com/nochebuenadev/go-kit/pkg/mw"
 )
 
 // mockLogger is a simple mock for logz.Logger.
@@ -13,14 +23,16 @@ type mockLogger struct {
 	logz.Logger
 }
 
-func (m *mockLogger) Debug(msg string, args ...any)               {}
-func (m *mockLogger) Info(msg string, args ...any)                {}
-func (m *mockLogger) Warn(msg string, args ...any)                {}
-func (m *mockLogger) Error(msg string, err error, args ...any)    {}
-func (m *mockLogger) LogError(msg string, err error, args ...any) {}
-func (m *mockLogger) Fatal(msg string, err error, args ...any)    {}
-func (m *mockLogger) With(args ...any) logz.Logger                { return m }
-func (m *mockLogger) WithContext(ctx context.Context) logz.Logger { return m }
+func (m *mockLogger) Debug(msg string, args ...any)                                       {}
+func (m *mockLogger) Info(msg string, args ...any)                                        {}
+func (m *mockLogger) Warn(msg string, args ...any)                                        {}
+func (m *mockLogger) Error(msg string, err error, args ...any)                            {}
+func (m *mockLogger) LogError(msg string, err error, args ...any)                         {}
+func (m *mockLogger) Fatal(msg string, err error, args ...any)                            {}
+func (m *mockLogger) WarnCtx(ctx context.Context, msg string, args ...any)                {}
+func (m *mockLogger) LogErrorCtx(ctx context.Context, msg string, err error, args ...any) {}
+func (m *mockLogger) With(args ...any) logz.Logger                                        { return m }
+func (m *mockLogger) WithContext(ctx context.Context) logz.Logger                         { return m }
 
 func TestGetPostgresClient(t *testing.T) {
 	cfg := &Config{Host: "localhost", Port: 5432}
@@ -37,6 +49,32 @@ func TestGetPostgresClient(t *testing.T) {
 	}
 }
 
+func TestNew(t *testing.T) {
+	logger := &mockLogger{}
+
+	c1 := New(logger, &Config{Host: "main"})
+	c2 := New(logger, &Config{Host: "analytics"})
+	if c1 == c2 {
+		t.Fatal("expected independent instances")
+	}
+	if c1.(*pgComponent).cfg.Host != "main" || c2.(*pgComponent).cfg.Host != "analytics" {
+		t.Error("expected each instance to keep its own config")
+	}
+
+	if c1.Name() != "postgres" {
+		t.Errorf("expected default name postgres, got %s", c1.Name())
+	}
+	if c3 := New(logger, &Config{}, WithName("analytics")); c3.Name() != "analytics" {
+		t.Errorf("expected name analytics, got %s", c3.Name())
+	}
+
+	Reset()
+	t.Cleanup(Reset)
+	if GetPostgresClient(logger, &Config{Host: "fresh"}).(*pgComponent).cfg.Host != "fresh" {
+		t.Error("expected a fresh singleton after Reset")
+	}
+}
+
 func TestDatabaseConfig_GetConnectionString(t *testing.T) {
 	cfg := &Config{
 		Host:     "localhost",
@@ -73,6 +111,31 @@ func TestPostgresClient_HandleError(t *testing.T) {
 			t.Errorf("expected INTERNAL_ERROR, got %s", ae.GetCode())
 		}
 	})
+
+	t.Run("retryable errors", func(t *testing.T) {
+		tests := []struct {
+			err       error
+			code      apperr.ErrorCode
+			temporary bool
+		}{
+			{&pgconn.PgError{Code: pgerrcode.SerializationFailure}, apperr.ErrAborted, false},
+			{&pgconn.PgError{Code: pgerrcode.DeadlockDetected}, apperr.ErrAborted, false},
+			{&pgconn.PgError{Code: pgerrcode.ConnectionFailure}, apperr.ErrUnavailable, true},
+			{&pgconn.PgError{Code: pgerrcode.CannotConnectNow}, apperr.ErrUnavailable, true},
+			{&net.OpError{Op: "dial", Err: errors.New("connection refused")}, apperr.ErrUnavailable, true},
+		}
+
+		for _, tt := range tests {
+			err := HandleError(tt.err)
+			if !apperr.IsCode(err, tt.code) || !apperr.IsRetryable(err) || apperr.IsTemporary(err) != tt.temporary {
+				t.Errorf("%v: unexpected classification of %v", tt.err, err)
+			}
+		}
+
+		if apperr.IsRetryable(HandleError(&pgconn.PgError{Code: pgerrcode.UniqueViolation})) {
+			t.Error("expected unique violations not to be retryable")
+		}
+	})
 }
 
 func TestErrRow(t *testing.T) {
@@ -84,3 +147,36 @@ func TestErrRow(t *testing.T) {
 		t.Errorf("expected %v, got %v", expectedErr, err)
 	}
 }
+
+func TestHandleError_Localized(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected string
+	}{
+		{&pgconn.PgError{Code: pgerrcode.UniqueViolation}, "the record already exists"},
+		{&pgconn.PgError{Code: pgerrcode.ForeignKeyViolation}, "data integrity violation"},
+		{&pgconn.PgError{Code: pgerrcode.DeadlockDetected}, "database concurrency conflict"},
+		{&pgconn.PgError{Code: pgerrcode.CannotConnectNow}, "database unavailable"},
+		{pgx.ErrNoRows, "record not found"},
+		{errors.New("boom"), "unexpected database error"},
+	}
+
+	handler := mw.AppErrorHandler(&mockLogger{})
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Accept-Language", "en")
+		rec := httptest.NewRecorder()
+
+		handler(HandleError(tt.err), echo.New().NewContext(req, rec))
+
+		var body struct {
+			Message string `json:"message"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		if body.Message != tt.expected {
+			t.Errorf("%v: expected %q, got %q", tt.err, tt.expected, body.Message)
+		}
+	}
+}
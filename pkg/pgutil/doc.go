@@ -6,6 +6,7 @@ and usage of pgxpool, and maps PostgreSQL errors onto standard application error
 
 Features:
 - Singleton database client with dbutil.Component implementation.
+- Independent instances via New, to run several pools in the same service.
 - Specialized wrappers for pgx.Row, pgx.Rows, and pgx.Tx to satisfy agnostic interfaces.
 - Standardized error handling (Unique violations, integrity violations, etc.).
 - Configurable connection pool using environment variables.
@@ -21,6 +22,14 @@ Example (Client):
 
 	err := db.GetExecutor(ctx).Exec(ctx, "INSERT INTO users (name) VALUES ($1)", "John")
 
+Example (Multiple pools):
+
+	mainCfg := config.MustLoad[pgutil.Config]()
+	analyticsCfg := config.MustLoad[pgutil.Config](config.WithPrefix("ANALYTICS_"))
+
+	mainDB := pgutil.New(logger, mainCfg)
+	analyticsDB := pgutil.New(logger, analyticsCfg)
+
 Example (Unit of Work):
 
 	uow := dbutil.GetUnitOfWork(logger, db)
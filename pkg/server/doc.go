@@ -6,11 +6,16 @@ lifecycle management (initialization, startup, and graceful shutdown), and integ
 logging and error handling.
 
 Features:
-- Singleton HttpServerComponent implementation based on Echo.
-- Pre-configured CORS, Recovery, RequestID, and Logging middlewares.
-- Custom error handling integrated with the mw package.
-- Graceful shutdown support.
-- Flexible route registration and grouping.
+  - Singleton HttpServerComponent implementation based on Echo, plus NewEchoServer for
+    independent instances (e.g. a public API and an admin port in the same process).
+  - Pre-configured CORS, Recovery, RequestID, Locale (Accept-Language), and Logging middlewares.
+  - Custom error handling integrated with the mw package; WithErrorHandlerOptions configures it,
+    e.g. mw.WithProblemDetails for RFC 9457 responses.
+  - Graceful shutdown support.
+  - Supervision: a server loop that stops unexpectedly is reported through Done
+    (launcher.Supervised), so the launcher can restart it or shut down the application.
+  - Reload: OnReload (launcher.Reloadable) applies the CORS origins read by WithConfigLoader.
+  - Flexible route registration and grouping.
 
 Example usage:
 
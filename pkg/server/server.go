@@ -5,7 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
@@ -28,6 +30,7 @@ type (
 	// HttpServerComponent extends RouterProvider with lifecycle management methods.
 	HttpServerComponent interface {
 		launcher.Component
+		launcher.Reloadable
 		RouterProvider
 	}
 
@@ -39,7 +42,28 @@ type (
 		logger logz.Logger
 		// cfg is the server configuration.
 		cfg *Config
+		// shutdownTimeout bounds the graceful shutdown performed in OnStop.
+		shutdownTimeout time.Duration
+		// name identifies the component in the launcher.
+		name string
+		// dependsOn lists the components that must be started before the server.
+		dependsOn []string
+		// mu guards done.
+		mu sync.Mutex
+		// done receives the error of the current server loop if it stops unexpectedly.
+		done chan error
+		// cors is the CORS middleware in use, replaced when the allowed origins are reloaded.
+		cors atomic.Pointer[echo.MiddlewareFunc]
+		// origins are the CORS origins in use.
+		origins []string
+		// loadConfig reloads the configuration on OnReload.
+		loadConfig func() (*Config, error)
+		// errorHandlerOpts configure the mw.AppErrorHandler installed by OnInit.
+		errorHandlerOpts []mw.ErrorHandlerOption
 	}
+
+	// Option configures an HTTP server created with NewEchoServer.
+	Option func(*echoServer)
 )
 
 var (
@@ -49,32 +73,100 @@ var (
 	serverOnce sync.Once
 )
 
+// WithEcho uses the given Echo instance instead of creating a new one.
+func WithEcho(e *echo.Echo) Option {
+	return func(s *echoServer) {
+		s.instance = e
+	}
+}
+
+// WithShutdownTimeout sets how long OnStop waits for in-flight requests (defaults to 10s).
+func WithShutdownTimeout(d time.Duration) Option {
+	return func(s *echoServer) {
+		s.shutdownTimeout = d
+	}
+}
+
+// WithName sets the name of the component in the launcher (defaults to "http-server").
+func WithName(name string) Option {
+	return func(s *echoServer) {
+		s.name = name
+	}
+}
+
+// WithDependencies declares the launcher components (by name) the server depends on,
+// e.g. "postgres" and "valkey", so it only starts accepting traffic once they are up.
+func WithDependencies(names ...string) Option {
+	return func(s *echoServer) {
+		s.dependsOn = append(s.dependsOn, names...)
+	}
+}
+
+// WithConfigLoader sets the function used by OnReload to read the configuration again,
+// e.g. config.Loader[server.Config](). Only AllowedOrigins is applied at runtime.
+func WithConfigLoader(load func() (*Config, error)) Option {
+	return func(s *echoServer) {
+		s.loadConfig = load
+	}
+}
+
+// WithErrorHandlerOptions configures the mw.AppErrorHandler installed by OnInit,
+// e.g. mw.WithProblemDetails to answer with application/problem+json.
+func WithErrorHandlerOptions(opts ...mw.ErrorHandlerOption) Option {
+	return func(s *echoServer) {
+		s.errorHandlerOpts = append(s.errorHandlerOpts, opts...)
+	}
+}
+
+// NewEchoServer creates a new, independent HTTP server. Unlike GetEchoServer it can be
+// called several times, e.g. to expose a public API and an admin port from the same process.
+func NewEchoServer(logger logz.Logger, cfg *Config, opts ...Option) HttpServerComponent {
+	s := &echoServer{
+		logger:          logger,
+		cfg:             cfg,
+		shutdownTimeout: 10 * time.Second,
+		name:            "http-server",
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.instance == nil {
+		s.instance = echo.New()
+	}
+	return s
+}
+
 // GetEchoServer returns the singleton instance of the HTTP server.
 func GetEchoServer(logger logz.Logger, cfg *Config) HttpServerComponent {
 	serverOnce.Do(func() {
-		serverInstance = &echoServer{
-			instance: echo.New(),
-			logger:   logger,
-			cfg:      cfg,
-		}
+		serverInstance = NewEchoServer(logger, cfg)
 	})
 
 	return serverInstance
 }
 
+// Reset discards the singleton returned by GetEchoServer so the next call builds a new one.
+// It is intended for tests and must not be called concurrently with GetEchoServer.
+func Reset() {
+	serverInstance = nil
+	serverOnce = sync.Once{}
+}
+
 // OnInit implements the launcher.Component interface to initialize the Echo instance with standard middlewares and error handling.
 func (s *echoServer) OnInit() error {
 	s.instance.HideBanner = true
 	s.instance.HidePort = true
 
-	s.instance.HTTPErrorHandler = mw.AppErrorHandler(s.logger)
+	s.instance.HTTPErrorHandler = mw.AppErrorHandler(s.logger, s.errorHandlerOpts...)
 
 	s.instance.Use(middleware.Recover())
 
-	s.instance.Use(middleware.CORSWithConfig(middleware.CORSConfig{
-		AllowOrigins: s.cfg.AllowedOrigins,
-		AllowMethods: []string{echo.GET, echo.HEAD, echo.PUT, echo.PATCH, echo.POST, echo.DELETE},
-	}))
+	s.setAllowedOrigins(s.cfg.AllowedOrigins)
+	s.instance.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			return (*s.cors.Load())(next)(c)
+		}
+	})
 
 	s.instance.Use(middleware.RequestIDWithConfig(middleware.RequestIDConfig{
 		Generator: func() string {
@@ -84,6 +176,8 @@ func (s *echoServer) OnInit() error {
 
 	s.instance.Use(mw.WithRequestID())
 
+	s.instance.Use(mw.WithLocale())
+
 	s.instance.Use(middleware.RequestLoggerWithConfig(middleware.RequestLoggerConfig{
 		LogStatus:    true,
 		LogMethod:    true,
@@ -113,13 +207,20 @@ func (s *echoServer) OnInit() error {
 }
 
 // OnStart implements the launcher.Component interface to start the Echo server in a separate goroutine.
+// If the server stops for any reason other than a shutdown, the error is reported through Done.
 func (s *echoServer) OnStart() error {
 	s.logger.Info("server: iniciando servidor HTTP", "port", s.cfg.Port)
 
+	done := make(chan error, 1)
+	s.mu.Lock()
+	s.done = done
+	s.mu.Unlock()
+
 	go func() {
 		addr := fmt.Sprintf(":%d", s.cfg.Port)
 		if err := s.instance.Start(addr); err != nil && !errors.Is(err, http.ErrServerClosed) {
 			s.logger.Error("server: error fatal en el servidor", err)
+			done <- err
 		}
 	}()
 
@@ -129,12 +230,53 @@ func (s *echoServer) OnStart() error {
 // OnStop implements the launcher.Component interface to gracefully shut down the Echo server with a timeout.
 func (s *echoServer) OnStop() error {
 	s.logger.Info("server: apagando servidor HTTP (Graceful Shutdown)")
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
 	defer cancel()
 
 	return s.instance.Shutdown(ctx)
 }
 
+// OnReload implements the launcher.Reloadable interface. It reads the configuration again with
+// the loader set by WithConfigLoader and applies the new CORS origins. Changes to the host or
+// port require a restart. Without a loader it does nothing.
+func (s *echoServer) OnReload(ctx context.Context) error {
+	if s.loadConfig == nil {
+		return nil
+	}
+
+	cfg, err := s.loadConfig()
+	if err != nil {
+		return err
+	}
+	if cfg.Host != s.cfg.Host || cfg.Port != s.cfg.Port {
+		s.logger.Warn("server: el cambio de host o puerto solo se aplica al reiniciar", "port", s.cfg.Port)
+	}
+
+	if !slices.Equal(cfg.AllowedOrigins, s.origins) {
+		s.logger.Info("server: actualizando orígenes CORS", "allowed_origins", cfg.AllowedOrigins)
+		s.setAllowedOrigins(cfg.AllowedOrigins)
+	}
+	return nil
+}
+
+// setAllowedOrigins builds the CORS middleware for the given origins and puts it in use.
+func (s *echoServer) setAllowedOrigins(origins []string) {
+	cors := middleware.CORSWithConfig(middleware.CORSConfig{
+		AllowOrigins: origins,
+		AllowMethods: []string{echo.GET, echo.HEAD, echo.PUT, echo.PATCH, echo.POST, echo.DELETE},
+	})
+	s.cors.Store(&cors)
+	s.origins = origins
+}
+
+// Done implements the launcher.Supervised interface. The channel receives the error of the
+// server loop started by the last OnStart if it stops unexpectedly.
+func (s *echoServer) Done() <-chan error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.done
+}
+
 // Registry registers routes using the provided function.
 func (s *echoServer) Registry(fn func(e *echo.Echo)) {
 	fn(s.instance)
@@ -144,3 +286,9 @@ func (s *echoServer) Registry(fn func(e *echo.Echo)) {
 func (s *echoServer) Group(prefix string) *echo.Group {
 	return s.instance.Group(prefix)
 }
+
+// Name implements the launcher.Named interface.
+func (s *echoServer) Name() string { return s.name }
+
+// DependsOn implements the launcher.Dependent interface.
+func (s *echoServer) DependsOn() []string { return s.dependsOn }
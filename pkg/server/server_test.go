@@ -1,33 +1,49 @@
 package server
 
 import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/labstack/echo/v4"
+	"github.com/nochebuenadev/go-kit/pkg/logz"
 )
 
 type mockLogger struct{}
 
-func (m *mockLogger) Debug(msg string, args ...any)               {}
-func (m *mockLogger) Info(msg string, args ...any)                {}
-func (m *mockLogger) Warn(msg string, args ...any)                {}
-func (m *mockLogger) Error(msg string, err error, args ...any)    {}
-func (m *mockLogger) LogError(msg string, err error, args ...any) {}
-func (m *mockLogger) Fatal(msg string, err error, args ...any)    {}
-func (m *mockLogger) With(args ...any) interface{}                { return m } // Simplified for mock
-func (m *mockLogger) WithContext(ctx interface{}) interface{}     { return m } // Simplified for mock
+func (m *mockLogger) Debug(msg string, args ...any)                                       {}
+func (m *mockLogger) Info(msg string, args ...any)                                        {}
+func (m *mockLogger) Warn(msg string, args ...any)                                        {}
+func (m *mockLogger) Error(msg string, err error, args ...any)                            {}
+func (m *mockLogger) LogError(msg string, err error, args ...any)                         {}
+func (m *mockLogger) Fatal(msg string, err error, args ...any)                            {}
+func (m *mockLogger) DebugCtx(ctx context.Context, msg string, args ...any)               {}
+func (m *mockLogger) InfoCtx(ctx context.Context, msg string, args ...any)                {}
+func (m *mockLogger) WarnCtx(ctx context.Context, msg string, args ...any)                {}
+func (m *mockLogger) ErrorCtx(ctx context.Context, msg string, err error, args ...any)    {}
+func (m *mockLogger) LogErrorCtx(ctx context.Context, msg string, err error, args ...any) {}
+func (m *mockLogger) With(args ...any) interface{}                                        { return m } // Simplified for mock
+func (m *mockLogger) WithContext(ctx interface{}) interface{}                             { return m } // Simplified for mock
 
 // Actually need to match logz.Logger interface exactly if I want to use it
 type fullMockLogger struct{}
 
-func (m *fullMockLogger) Debug(msg string, args ...any)               {}
-func (m *fullMockLogger) Info(msg string, args ...any)                {}
-func (m *fullMockLogger) Warn(msg string, args ...any)                {}
-func (m *fullMockLogger) Error(msg string, err error, args ...any)    {}
-func (m *fullMockLogger) LogError(msg string, err error, args ...any) {}
-func (m *fullMockLogger) Fatal(msg string, err error, args ...any)    {}
-func (m *fullMockLogger) With(args ...any) any                        { return m }
-func (m *fullMockLogger) WithContext(ctx any) any                     { return m }
+func (m *fullMockLogger) Debug(msg string, args ...any)                                       {}
+func (m *fullMockLogger) Info(msg string, args ...any)                                        {}
+func (m *fullMockLogger) Warn(msg string, args ...any)                                        {}
+func (m *fullMockLogger) Error(msg string, err error, args ...any)                            {}
+func (m *fullMockLogger) LogError(msg string, err error, args ...any)                         {}
+func (m *fullMockLogger) Fatal(msg string, err error, args ...any)                            {}
+func (m *fullMockLogger) DebugCtx(ctx context.Context, msg string, args ...any)               {}
+func (m *fullMockLogger) InfoCtx(ctx context.Context, msg string, args ...any)                {}
+func (m *fullMockLogger) WarnCtx(ctx context.Context, msg string, args ...any)                {}
+func (m *fullMockLogger) ErrorCtx(ctx context.Context, msg string, err error, args ...any)    {}
+func (m *fullMockLogger) LogErrorCtx(ctx context.Context, msg string, err error, args ...any) {}
+func (m *fullMockLogger) With(args ...any) any                                                { return m }
+func (m *fullMockLogger) WithContext(ctx any) any                                             { return m }
 
 // The above is still not quite right because logz.Logger is an interface.
 // I'll just use the mock I used in pgutil but adapt it to logz.Logger interface.
@@ -51,6 +67,34 @@ func TestGetEchoServer(t *testing.T) {
 	}
 }
 
+func TestNewEchoServer(t *testing.T) {
+	cfg := &Config{Port: 8080, AllowedOrigins: []string{"*"}}
+	e := echo.New()
+
+	srv := NewEchoServer(nil, cfg, WithEcho(e), WithShutdownTimeout(time.Second)).(*echoServer)
+	if srv.instance != e {
+		t.Error("expected the provided Echo instance to be used")
+	}
+	if srv.shutdownTimeout != time.Second {
+		t.Errorf("expected shutdown timeout 1s, got %v", srv.shutdownTimeout)
+	}
+
+	if NewEchoServer(nil, cfg) == NewEchoServer(nil, cfg) {
+		t.Error("expected independent instances")
+	}
+}
+
+func TestReset(t *testing.T) {
+	cfg := &Config{Port: 8080, AllowedOrigins: []string{"*"}}
+	srv := GetEchoServer(nil, cfg)
+
+	Reset()
+
+	if GetEchoServer(nil, cfg) == srv {
+		t.Error("expected a new instance after Reset")
+	}
+}
+
 func TestEchoServer_OnInit(t *testing.T) {
 	cfg := &Config{
 		Port:           8080,
@@ -91,3 +135,71 @@ func TestEchoServer_Group(t *testing.T) {
 		t.Fatal("expected group, got nil")
 	}
 }
+
+// noopLogger implements logz.Logger, discarding every entry.
+type noopLogger struct {
+	logz.Logger
+}
+
+func (m *noopLogger) Info(msg string, args ...any)             {}
+func (m *noopLogger) Warn(msg string, args ...any)             {}
+func (m *noopLogger) Error(msg string, err error, args ...any) {}
+
+func TestEchoServer_Done(t *testing.T) {
+	// Occupy a port so the server loop fails right after OnStart.
+	ln, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	cfg := &Config{Port: ln.Addr().(*net.TCPAddr).Port}
+	srv := NewEchoServer(&noopLogger{}, cfg).(*echoServer)
+
+	if err := srv.OnStart(); err != nil {
+		t.Fatalf("OnStart failed: %v", err)
+	}
+
+	select {
+	case err := <-srv.Done():
+		if err == nil {
+			t.Error("expected the server error to be reported")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected Done to report the failed server loop")
+	}
+}
+
+func TestEchoServer_OnReload(t *testing.T) {
+	origins := []string{"https://a.example.com"}
+	srv := NewEchoServer(&noopLogger{}, &Config{Port: 8080, AllowedOrigins: origins},
+		WithConfigLoader(func() (*Config, error) {
+			return &Config{Port: 8080, AllowedOrigins: origins}, nil
+		}),
+	).(*echoServer)
+	if err := srv.OnInit(); err != nil {
+		t.Fatalf("OnInit failed: %v", err)
+	}
+	srv.instance.GET("/", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+
+	allowed := func(origin string) bool {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(echo.HeaderOrigin, origin)
+		rec := httptest.NewRecorder()
+		srv.instance.ServeHTTP(rec, req)
+		return rec.Header().Get(echo.HeaderAccessControlAllowOrigin) == origin
+	}
+
+	if !allowed("https://a.example.com") || allowed("https://b.example.com") {
+		t.Fatal("unexpected initial CORS configuration")
+	}
+
+	origins = []string{"https://b.example.com"}
+	if err := srv.OnReload(context.Background()); err != nil {
+		t.Fatalf("OnReload failed: %v", err)
+	}
+
+	if allowed("https://a.example.com") || !allowed("https://b.example.com") {
+		t.Error("expected the reloaded origins to be applied")
+	}
+}
@@ -32,15 +32,33 @@ type (
 		cfg *Config
 		// logger is used for tracking valkey operations.
 		logger logz.Logger
+		// name identifies the component in health reports and the launcher.
+		name string
 	}
+
+	// Option configures a ValkeyComponent created with New.
+	Option func(*vkComponent)
 )
 
+// WithName sets the name reported by the component (defaults to "valkey").
+// Use it to tell several clients apart in health reports.
+func WithName(name string) Option {
+	return func(v *vkComponent) {
+		v.name = name
+	}
+}
+
 // New creates a new ValkeyComponent instance.
-func New(logger logz.Logger, cfg *Config) ValkeyComponent {
-	return &vkComponent{
+func New(logger logz.Logger, cfg *Config, opts ...Option) ValkeyComponent {
+	v := &vkComponent{
 		cfg:    cfg,
 		logger: logger,
+		name:   "valkey",
+	}
+	for _, opt := range opts {
+		opt(v)
 	}
+	return v
 }
 
 // OnInit initializes the Valkey client with the provided configuration.
@@ -103,7 +121,7 @@ func (v *vkComponent) HealthCheck(ctx context.Context) error {
 }
 
 // Name implements the health.Checkable interface.
-func (v *vkComponent) Name() string { return "valkey" }
+func (v *vkComponent) Name() string { return v.name }
 
 // Priority implements the health.Checkable interface.
 func (v *vkComponent) Priority() health.Level { return health.LevelDegraded }
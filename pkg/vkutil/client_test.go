@@ -22,6 +22,16 @@ func TestNew(t *testing.T) {
 	}
 }
 
+func TestNew_WithName(t *testing.T) {
+	v := New(&mockLogger{}, &Config{}, WithName("sessions"))
+	if v.Name() != "sessions" {
+		t.Errorf("expected name sessions, got %s", v.Name())
+	}
+	if New(&mockLogger{}, &Config{}).Name() != "valkey" {
+		t.Error("expected default name valkey")
+	}
+}
+
 func TestVkComponent_OnInit_Config(t *testing.T) {
 	cfg := &Config{
 		Addrs:             []string{"localhost:6379"},
@@ -6,18 +6,24 @@ ensuring that the main application flow remains responsive. It includes built-in
 backpressure handling and graceful shutdown.
 
 Features:
-- Configurable pool size and buffer capacity.
-- Thread-safe task dispatching.
-- Graceful shutdown: Processes remaining tasks in the queue before exit.
-- Integration with launcher.Component for lifecycle management.
+  - Configurable pool size and buffer capacity.
+  - Singleton pool via GetWorker, or independent pools via New.
+  - Thread-safe task dispatching.
+  - Graceful shutdown: Processes remaining tasks in the queue before exit.
+  - Integration with launcher.Component for lifecycle management.
+  - Supervision: a worker killed by a panicking task is reported through Done
+    (launcher.Supervised), and restarting the pool replaces only the dead workers.
+  - Runtime resizing: Resize changes the number of workers without dropping queued tasks,
+    and OnReload (launcher.Reloadable) applies the PoolSize read by WithConfigLoader.
 
 Example usage:
 
 	cfg := &worker.Config{PoolSize: 10, BufferSize: 100}
 	wk := worker.GetWorker(cfg, logger)
 
-	// In a handler or service:
-	wk.Dispatch(func(ctx context.Context) error {
+	// In a handler or service, passing the request context so the logs of the task keep
+	// its request fields:
+	wk.DispatchCtx(c.Request().Context(), func(ctx context.Context) error {
 		// Do background work here
 		return nil
 	})
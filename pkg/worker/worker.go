@@ -2,6 +2,7 @@ package worker
 
 import (
 	"context"
+	"fmt"
 	"sync"
 
 	"github.com/nochebuenadev/go-kit/pkg/launcher"
@@ -17,12 +18,19 @@ type (
 		// Dispatch adds a task to the queue. Returns true if the task was queued,
 		// or false if the queue is full (backpressure).
 		Dispatch(task Task) bool
+		// DispatchCtx is like Dispatch, but logs the task with the request fields of ctx, the
+		// context of the caller. The task itself runs with the context of the pool.
+		DispatchCtx(ctx context.Context, task Task) bool
 	}
 
 	// Component extends Provider with lifecycle management methods.
 	Component interface {
 		launcher.Component
+		launcher.Reloadable
 		Provider
+		// Resize changes the number of workers of a running pool. Workers being removed
+		// finish their current task, and queued tasks are kept for the remaining workers.
+		Resize(size int)
 	}
 
 	// workerComponent is the concrete implementation of the worker pool.
@@ -32,14 +40,41 @@ type (
 		// cfg is the worker pool configuration.
 		cfg *Config
 		// taskQueue is the channel used to dispatch tasks to workers.
-		taskQueue chan Task
+		taskQueue chan job
 		// wg tracks the lifecycle of active worker goroutines.
 		wg sync.WaitGroup
 		// ctx is the background context for worker tasks.
 		ctx context.Context
 		// cancel is used to signal workers to stop.
 		cancel context.CancelFunc
+		// name identifies the component in the launcher.
+		name string
+		// dependsOn lists the components that must be started before the pool.
+		dependsOn []string
+		// mu guards size, workers and nextID.
+		mu sync.Mutex
+		// size is the desired number of workers.
+		size int
+		// workers maps the running workers to the channel that tells them to exit.
+		workers map[int]chan struct{}
+		// nextID is the id of the next worker.
+		nextID int
+		// done receives an error for every worker goroutine that dies because of a panic.
+		done chan error
+		// loadConfig reloads the configuration on OnReload.
+		loadConfig func() (*Config, error)
 	}
+
+	// job is a queued task with the context of its caller.
+	job struct {
+		// ctx is the context passed to Dispatch, used for logging.
+		ctx context.Context
+		// task is the work to run.
+		task Task
+	}
+
+	// Option configures a worker pool created with New.
+	Option func(*workerComponent)
 )
 
 var (
@@ -49,21 +84,72 @@ var (
 	once sync.Once
 )
 
+// WithBaseContext sets the parent of the context passed to tasks (defaults to context.Background).
+// Values stored in it are visible to every task; it is cancelled when the pool stops.
+func WithBaseContext(ctx context.Context) Option {
+	return func(w *workerComponent) {
+		w.ctx = ctx
+	}
+}
+
+// WithName sets the name of the component in the launcher (defaults to "worker").
+func WithName(name string) Option {
+	return func(w *workerComponent) {
+		w.name = name
+	}
+}
+
+// WithDependencies declares the launcher components (by name) the pool depends on,
+// e.g. the databases used by its tasks.
+func WithDependencies(names ...string) Option {
+	return func(w *workerComponent) {
+		w.dependsOn = append(w.dependsOn, names...)
+	}
+}
+
+// WithConfigLoader sets the function used by OnReload to read the configuration again,
+// e.g. config.Loader[worker.Config](). Only PoolSize is applied at runtime.
+func WithConfigLoader(load func() (*Config, error)) Option {
+	return func(w *workerComponent) {
+		w.loadConfig = load
+	}
+}
+
+// New creates a new, independent worker pool. Unlike GetWorker it can be called several
+// times, e.g. to isolate slow tasks in their own pool.
+func New(logger logz.Logger, cfg *Config, opts ...Option) Component {
+	w := &workerComponent{
+		logger:    logger,
+		cfg:       cfg,
+		taskQueue: make(chan job, cfg.BufferSize),
+		ctx:       context.Background(),
+		name:      "worker",
+		size:      cfg.PoolSize,
+		workers:   make(map[int]chan struct{}),
+		done:      make(chan error, max(cfg.PoolSize, 1)),
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	w.ctx, w.cancel = context.WithCancel(w.ctx)
+	return w
+}
+
 // GetWorker returns the singleton instance of the worker component.
 func GetWorker(logger logz.Logger, cfg *Config) Component {
 	once.Do(func() {
-		ctx, cancel := context.WithCancel(context.Background())
-		instance = &workerComponent{
-			logger:    logger,
-			cfg:       cfg,
-			taskQueue: make(chan Task, cfg.BufferSize),
-			ctx:       ctx,
-			cancel:    cancel,
-		}
+		instance = New(logger, cfg)
 	})
 	return instance
 }
 
+// Reset discards the singleton returned by GetWorker so the next call builds a new one.
+// It is intended for tests and must not be called concurrently with GetWorker.
+func Reset() {
+	instance = nil
+	once = sync.Once{}
+}
+
 // OnInit implements the launcher.Component interface to initialize the worker pool.
 func (w *workerComponent) OnInit() error {
 	w.logger.Info("worker: inicializando pool de sombras",
@@ -73,45 +159,154 @@ func (w *workerComponent) OnInit() error {
 }
 
 // OnStart implements the launcher.Component interface to start the background workers.
+// Calling it again, e.g. when the launcher restarts the pool, only replaces the workers that died.
 func (w *workerComponent) OnStart() error {
 	w.logger.Info("worker: activando workers en segundo plano")
-	for i := 0; i < w.cfg.PoolSize; i++ {
-		w.wg.Add(1)
-		go func(id int) {
-			defer w.wg.Done()
-			w.runWorker(id)
-		}(i)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.scale()
+	return nil
+}
+
+// Resize implements Component.
+func (w *workerComponent) Resize(size int) {
+	size = max(size, 0)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.logger.Info("worker: redimensionando pool", "from", w.size, "to", size)
+	w.size = size
+	w.scale()
+}
+
+// OnReload implements the launcher.Reloadable interface. It reads the configuration again with
+// the loader set by WithConfigLoader and resizes the pool. Without a loader it does nothing.
+func (w *workerComponent) OnReload(ctx context.Context) error {
+	if w.loadConfig == nil {
+		return nil
+	}
+
+	cfg, err := w.loadConfig()
+	if err != nil {
+		return err
+	}
+	if cfg.BufferSize != w.cfg.BufferSize {
+		w.logger.Warn("worker: el tamaño del buffer solo se aplica al reiniciar", "buffer_size", w.cfg.BufferSize)
 	}
+
+	w.Resize(cfg.PoolSize)
 	return nil
 }
 
+// scale starts or stops workers until the pool has the desired size. It must be called
+// with mu held.
+func (w *workerComponent) scale() {
+	for len(w.workers) < w.size {
+		w.startWorker()
+	}
+
+	for id, quit := range w.workers {
+		if len(w.workers) <= w.size {
+			break
+		}
+		close(quit)
+		delete(w.workers, id)
+	}
+}
+
+// startWorker starts a new worker. It must be called with mu held.
+func (w *workerComponent) startWorker() {
+	id, quit := w.nextID, make(chan struct{})
+	w.nextID++
+	w.workers[id] = quit
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		w.runWorker(id, quit)
+	}()
+}
+
 // OnStop implements the launcher.Component interface to gracefully shut down the worker pool,
-// processing remaining tasks before exiting.
+// processing remaining tasks before exiting. Tasks left without workers, after Resize(0) or
+// because panics killed them, are processed by workers started for that purpose.
 func (w *workerComponent) OnStop() error {
 	w.logger.Info("worker: apagando pool, procesando tareas pendientes")
 	close(w.taskQueue)
 	w.cancel()
 	w.wg.Wait()
+
+	for len(w.taskQueue) > 0 {
+		w.mu.Lock()
+		w.startWorker()
+		w.mu.Unlock()
+		w.wg.Wait()
+	}
 	w.logger.Info("worker: pool de sombras cerrado correctamente")
 	return nil
 }
 
 // Dispatch adds a task to the worker queue. It returns false if the queue is full.
 func (w *workerComponent) Dispatch(task Task) bool {
+	return w.DispatchCtx(context.Background(), task)
+}
+
+// DispatchCtx implements Provider.
+func (w *workerComponent) DispatchCtx(ctx context.Context, task Task) bool {
 	select {
-	case w.taskQueue <- task:
+	case w.taskQueue <- job{ctx: ctx, task: task}:
 		return true
 	default:
-		w.logger.Error("worker: sobrecarga - cola llena, tarea ignorada", nil)
+		w.logger.ErrorCtx(ctx, "worker: sobrecarga - cola llena, tarea ignorada", nil)
 		return false
 	}
 }
 
-// runWorker is the internal loop for each worker goroutine.
-func (w *workerComponent) runWorker(id int) {
-	for task := range w.taskQueue {
-		if err := task(w.ctx); err != nil {
-			w.logger.Error("worker: error ejecutando tarea", err, "worker_id", id)
+// Done implements the launcher.Supervised interface. The channel receives an error for every
+// worker that dies because a task panicked.
+func (w *workerComponent) Done() <-chan error { return w.done }
+
+// Name implements the launcher.Named interface.
+func (w *workerComponent) Name() string { return w.name }
+
+// DependsOn implements the launcher.Dependent interface.
+func (w *workerComponent) DependsOn() []string { return w.dependsOn }
+
+// runWorker is the internal loop for each worker goroutine. It exits when the queue is closed
+// or quit is closed by a resize. A panicking task ends the worker, which is reported through
+// Done so the launcher can restart it.
+func (w *workerComponent) runWorker(id int, quit chan struct{}) {
+	// ctx is the context of the task being run, used to log a panic.
+	ctx := w.ctx
+	defer func() {
+		w.mu.Lock()
+		delete(w.workers, id)
+		w.mu.Unlock()
+
+		if r := recover(); r != nil {
+			err := fmt.Errorf("worker: pánico en la tarea: %v", r)
+			w.logger.ErrorCtx(ctx, "worker: el worker terminó inesperadamente", err, "worker_id", id)
+			select {
+			case w.done <- err:
+			default:
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-quit:
+			return
+		case j, ok := <-w.taskQueue:
+			if !ok {
+				return
+			}
+			ctx = j.ctx
+			if err := j.task(w.ctx); err != nil {
+				w.logger.ErrorCtx(ctx, "worker: error ejecutando tarea", err, "worker_id", id)
+			}
 		}
 	}
 }
@@ -11,14 +11,20 @@ import (
 
 type mockLogger struct{}
 
-func (m *mockLogger) Debug(msg string, args ...any)               {}
-func (m *mockLogger) Info(msg string, args ...any)                {}
-func (m *mockLogger) Warn(msg string, args ...any)                {}
-func (m *mockLogger) Error(msg string, err error, args ...any)    {}
-func (m *mockLogger) LogError(msg string, err error, args ...any) {}
-func (m *mockLogger) Fatal(msg string, err error, args ...any)    {}
-func (m *mockLogger) With(args ...any) logz.Logger                { return m }
-func (m *mockLogger) WithContext(ctx context.Context) logz.Logger { return m }
+func (m *mockLogger) Debug(msg string, args ...any)                                       {}
+func (m *mockLogger) Info(msg string, args ...any)                                        {}
+func (m *mockLogger) Warn(msg string, args ...any)                                        {}
+func (m *mockLogger) Error(msg string, err error, args ...any)                            {}
+func (m *mockLogger) LogError(msg string, err error, args ...any)                         {}
+func (m *mockLogger) Fatal(msg string, err error, args ...any)                            {}
+func (m *mockLogger) DebugCtx(ctx context.Context, msg string, args ...any)               {}
+func (m *mockLogger) InfoCtx(ctx context.Context, msg string, args ...any)                {}
+func (m *mockLogger) WarnCtx(ctx context.Context, msg string, args ...any)                {}
+func (m *mockLogger) ErrorCtx(ctx context.Context, msg string, err error, args ...any)    {}
+func (m *mockLogger) LogErrorCtx(ctx context.Context, msg string, err error, args ...any) {}
+func (m *mockLogger) With(args ...any) logz.Logger                                        { return m }
+func (m *mockLogger) WithContext(ctx context.Context) logz.Logger                         { return m }
+func (m *mockLogger) Named(name string) logz.Logger                                       { return m }
 
 func TestGetWorker(t *testing.T) {
 	cfg := &Config{PoolSize: 2, BufferSize: 5}
@@ -33,10 +39,32 @@ func TestGetWorker(t *testing.T) {
 	}
 }
 
+func TestNew(t *testing.T) {
+	type ctxKey struct{}
+	base := context.WithValue(context.Background(), ctxKey{}, "value")
+
+	cfg := &Config{PoolSize: 1, BufferSize: 1}
+	w1 := New(&mockLogger{}, cfg, WithBaseContext(base))
+	w2 := New(&mockLogger{}, cfg)
+	if w1 == w2 {
+		t.Fatal("expected independent instances")
+	}
+
+	_ = w1.OnStart()
+	done := make(chan any, 1)
+	w1.Dispatch(func(ctx context.Context) error {
+		done <- ctx.Value(ctxKey{})
+		return nil
+	})
+	if v := <-done; v != "value" {
+		t.Errorf("expected task context to inherit base context values, got %v", v)
+	}
+	_ = w1.OnStop()
+}
+
 func TestWorkerComponent_Lifecycle(t *testing.T) {
 	// Reset singleton for test
-	instance = nil
-	once = sync.Once{}
+	Reset()
 
 	cfg := &Config{PoolSize: 2, BufferSize: 5}
 	w := GetWorker(&mockLogger{}, cfg)
@@ -81,8 +109,7 @@ func TestWorkerComponent_Lifecycle(t *testing.T) {
 
 func TestWorkerComponent_Backpressure(t *testing.T) {
 	// Reset singleton for test
-	instance = nil
-	once = sync.Once{}
+	Reset()
 
 	cfg := &Config{PoolSize: 0, BufferSize: 1} // No workers, buffer size 1
 	w := GetWorker(&mockLogger{}, cfg)
@@ -100,8 +127,7 @@ func TestWorkerComponent_Backpressure(t *testing.T) {
 
 func TestWorkerComponent_ErrorHandling(t *testing.T) {
 	// Reset singleton for test
-	instance = nil
-	once = sync.Once{}
+	Reset()
 
 	cfg := &Config{PoolSize: 1, BufferSize: 1}
 	w := GetWorker(&mockLogger{}, cfg)
@@ -121,3 +147,150 @@ func TestWorkerComponent_ErrorHandling(t *testing.T) {
 
 	w.OnStop()
 }
+
+// ctxLogger records the contexts passed to ErrorCtx.
+type ctxLogger struct {
+	mockLogger
+	ctxs chan context.Context
+}
+
+func (m *ctxLogger) ErrorCtx(ctx context.Context, msg string, err error, args ...any) { m.ctxs <- ctx }
+
+func TestWorkerComponent_DispatchCtx(t *testing.T) {
+	type ctxKey struct{}
+	ctx := context.WithValue(context.Background(), ctxKey{}, "req-1")
+
+	logger := &ctxLogger{ctxs: make(chan context.Context, 1)}
+	w := New(logger, &Config{PoolSize: 1, BufferSize: 1})
+	_ = w.OnStart()
+	defer func() { _ = w.OnStop() }()
+
+	w.DispatchCtx(ctx, func(ctx context.Context) error {
+		return errors.New("task failed")
+	})
+
+	if got := <-logger.ctxs; got.Value(ctxKey{}) != "req-1" {
+		t.Error("expected the error to be logged with the context passed to DispatchCtx")
+	}
+}
+
+func TestWorkerComponent_Supervision(t *testing.T) {
+	cfg := &Config{PoolSize: 1, BufferSize: 2}
+	w := New(&mockLogger{}, cfg).(*workerComponent)
+	_ = w.OnStart()
+
+	w.Dispatch(func(ctx context.Context) error {
+		panic("boom")
+	})
+
+	if err := <-w.Done(); err == nil {
+		t.Fatal("expected the dead worker to be reported")
+	}
+
+	// A restart replaces the dead worker so tasks are processed again.
+	_ = w.OnStart()
+	done := make(chan struct{})
+	w.Dispatch(func(ctx context.Context) error {
+		close(done)
+		return nil
+	})
+	<-done
+
+	w.mu.Lock()
+	alive := len(w.workers)
+	w.mu.Unlock()
+	if alive != cfg.PoolSize {
+		t.Errorf("expected %d workers after restart, got %d", cfg.PoolSize, alive)
+	}
+
+	_ = w.OnStop()
+}
+
+func TestWorkerComponent_Resize(t *testing.T) {
+	cfg := &Config{PoolSize: 1, BufferSize: 10}
+	w := New(&mockLogger{}, cfg).(*workerComponent)
+	_ = w.OnStart()
+
+	// Block the only worker so the following tasks stay queued.
+	release := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(4)
+	w.Dispatch(func(ctx context.Context) error {
+		defer wg.Done()
+		<-release
+		return nil
+	})
+	for i := 0; i < 3; i++ {
+		w.Dispatch(func(ctx context.Context) error {
+			wg.Done()
+			return nil
+		})
+	}
+
+	w.Resize(0)
+	w.Resize(3)
+	close(release)
+	wg.Wait()
+
+	w.mu.Lock()
+	workers := len(w.workers)
+	w.mu.Unlock()
+	if workers != 3 {
+		t.Errorf("expected 3 workers, got %d", workers)
+	}
+
+	_ = w.OnStop()
+}
+
+func TestWorkerComponent_OnReload(t *testing.T) {
+	cfg := &Config{PoolSize: 1, BufferSize: 1}
+	w := New(&mockLogger{}, cfg, WithConfigLoader(func() (*Config, error) {
+		return &Config{PoolSize: 4, BufferSize: 1}, nil
+	})).(*workerComponent)
+	_ = w.OnStart()
+
+	if err := w.OnReload(context.Background()); err != nil {
+		t.Fatalf("OnReload failed: %v", err)
+	}
+
+	w.mu.Lock()
+	workers := len(w.workers)
+	w.mu.Unlock()
+	if workers != 4 {
+		t.Errorf("expected the pool to be resized to 4, got %d", workers)
+	}
+
+	_ = w.OnStop()
+
+	failing := New(&mockLogger{}, cfg, WithConfigLoader(func() (*Config, error) {
+		return nil, errors.New("invalid config")
+	}))
+	if err := failing.OnReload(context.Background()); err == nil {
+		t.Error("expected the loader error to be returned")
+	}
+}
+
+func TestWorkerComponent_StopAfterResizeToZero(t *testing.T) {
+	w := New(&mockLogger{}, &Config{PoolSize: 1, BufferSize: 5})
+	_ = w.OnStart()
+	w.Resize(0)
+
+	var mu sync.Mutex
+	ran := 0
+	for i := 0; i < 3; i++ {
+		w.Dispatch(func(ctx context.Context) error {
+			mu.Lock()
+			defer mu.Unlock()
+			ran++
+			return nil
+		})
+	}
+
+	_ = w.OnStop()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if ran != 3 {
+		t.Errorf("expected the queued tasks to run on stop, got %d", ran)
+	}
+}